@@ -0,0 +1,287 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/signal"
+	"slices"
+	"strings"
+	"syscall"
+
+	"static-site-generator/pkg/config"
+	"static-site-generator/pkg/fileutils"
+	"static-site-generator/pkg/generator"
+	"static-site-generator/pkg/server"
+	"static-site-generator/pkg/watcher"
+)
+
+type options struct {
+	configPath     string
+	staticDir      string
+	contentDir     string
+	dataDir        string
+	destinationDir string
+	templatePath   string
+	layoutsDir     string
+	serverPort     int
+	baseURL        string
+	drafts         bool
+	future         bool
+	expired        bool
+	workers        int
+	force          bool
+	watch          bool
+
+	args []string
+}
+
+func newFlagSet(name string, opts *options) *flag.FlagSet {
+	defaults := config.Default()
+
+	flagSet := flag.NewFlagSet(name, flag.ContinueOnError)
+
+	flagSet.StringVar(&opts.configPath, "config", config.DefaultPath, "path of the site config file")
+	flagSet.StringVar(&opts.staticDir, "static", defaults.StaticDir, "directory of static files")
+	flagSet.StringVar(&opts.contentDir, "content", defaults.ContentDir, "directory of Markdown content")
+	flagSet.StringVar(&opts.dataDir, "data", defaults.DataDir, "directory of JSON and CSV data files")
+	flagSet.StringVar(&opts.destinationDir, "destination", defaults.DestinationDir, "directory to generate pages into")
+	flagSet.StringVar(&opts.templatePath, "template", defaults.TemplatePath, "path of the HTML template used if layouts directory doesn't exist")
+	flagSet.StringVar(&opts.layoutsDir, "layouts", defaults.LayoutsDir, "directory of HTML layouts")
+	flagSet.IntVar(&opts.serverPort, "port", defaults.ServerPort, "port to serve pages on")
+	flagSet.StringVar(&opts.baseURL, "baseURL", defaults.BaseURL, "absolute URL the site is published at")
+	flagSet.BoolVar(&opts.drafts, "drafts", defaults.BuildDrafts, "include pages marked as draft")
+	flagSet.BoolVar(&opts.future, "future", defaults.BuildFuture, "include pages dated in the future")
+	flagSet.BoolVar(&opts.expired, "expired", defaults.BuildExpired, "include pages past their expiry date")
+	flagSet.IntVar(&opts.workers, "workers", defaults.Workers, "number of pages generated in parallel, GOMAXPROCS if 0")
+	flagSet.BoolVar(&opts.force, "force", false, "ignore manifest of the previous build and regenerate everything")
+	flagSet.BoolVar(&opts.watch, "watch", name == "serve", "rebuild when content, static files, layouts or config change")
+
+	return flagSet
+}
+
+// loadConfig parses command's flags, loads the site config and overrides it with explicitly set
+// flags. Missing config file at the default path is not an error, defaults are used instead.
+func loadConfig(name string, args []string) (*config.Config, *options, error) {
+	opts := &options{}
+
+	flagSet := newFlagSet(name, opts)
+	if err := flagSet.Parse(args); err != nil {
+		return nil, nil, err
+	}
+
+	isFlagSet := map[string]bool{}
+	flagSet.Visit(func(f *flag.Flag) { isFlagSet[f.Name] = true })
+
+	cfg, err := config.Load(opts.configPath)
+	if errors.Is(err, fs.ErrNotExist) && !isFlagSet["config"] {
+		fmt.Printf("Config file (%s) not found, using default config.\n", opts.configPath)
+		cfg, err = config.Default(), nil
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	flagSet.Visit(func(f *flag.Flag) {
+		switch f.Name {
+		case "static":
+			cfg.StaticDir = opts.staticDir
+		case "content":
+			cfg.ContentDir = opts.contentDir
+		case "data":
+			cfg.DataDir = opts.dataDir
+		case "destination":
+			cfg.DestinationDir = opts.destinationDir
+		case "template":
+			cfg.TemplatePath = opts.templatePath
+		case "layouts":
+			cfg.LayoutsDir = opts.layoutsDir
+		case "port":
+			cfg.ServerPort = opts.serverPort
+		case "baseURL":
+			cfg.BaseURL = opts.baseURL
+		case "drafts":
+			cfg.BuildDrafts = opts.drafts
+		case "future":
+			cfg.BuildFuture = opts.future
+		case "expired":
+			cfg.BuildExpired = opts.expired
+		case "workers":
+			cfg.Workers = opts.workers
+		}
+	})
+
+	if err := cfg.Validate(); err != nil {
+		return nil, nil, err
+	}
+
+	opts.args = flagSet.Args()
+
+	return cfg, opts, nil
+}
+
+func runBuild(args []string) error {
+	cfg, opts, err := loadConfig("build", args)
+	if err != nil {
+		return err
+	}
+
+	if !opts.watch {
+		return build(cfg, opts.force)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	// Errors shouldn't stop watching, the next change may fix them.
+	reportError(build(cfg, opts.force))
+
+	return watch(ctx, "build", args, cfg, opts)
+}
+
+func runServe(args []string) error {
+	cfg, opts, err := loadConfig("serve", args)
+	if err != nil {
+		return err
+	}
+
+	if err := build(cfg, opts.force); err != nil {
+		if !opts.watch {
+			return err
+		}
+
+		reportError(err)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	watchErr := make(chan error, 1)
+
+	if opts.watch {
+		go func() { watchErr <- watch(ctx, "serve", args, cfg, opts) }()
+	} else {
+		watchErr <- nil
+	}
+
+	serveErr := server.ServeDir(ctx, cfg.DestinationDir, fmt.Sprintf(":%d", cfg.ServerPort))
+	stop()
+
+	return errors.Join(serveErr, <-watchErr)
+}
+
+func runClean(args []string) error {
+	cfg, _, err := loadConfig("clean", args)
+	if err != nil {
+		return err
+	}
+
+	if err := fileutils.CleanDestinationDir(cfg.DestinationDir); err != nil {
+		return err
+	}
+
+	err = os.Remove(cfg.ManifestPath)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("removing manifest (%s) failed: %v", cfg.ManifestPath, err)
+	}
+
+	return nil
+}
+
+func runNew(args []string) error {
+	cfg, opts, err := loadConfig("new", args)
+	if err != nil {
+		return err
+	}
+
+	if len(opts.args) != 1 {
+		return errors.New("new expects exactly one argument: path of the page relative to the content directory")
+	}
+
+	return generator.CreatePage(cfg.ContentDir, opts.args[0])
+}
+
+func build(cfg *config.Config, force bool) error {
+	summary, err := generator.Build(cfg, generator.BuildOptions{Force: force})
+	if err != nil {
+		return err
+	}
+
+	summary.Print()
+
+	return nil
+}
+
+// watch rebuilds the site whenever content, static files, layouts or the config file change,
+// until ctx is done. Changed config file is reloaded with the same flags, so that flags keep
+// overriding it, unless checkReload rejects it. Build errors are reported and watching continues.
+func watch(ctx context.Context, name string, args []string, cfg *config.Config, opts *options) error {
+	siteWatcher := watcher.New(watchedPaths(cfg, opts)...)
+
+	fmt.Printf(
+		"Watching for changes in %s. Press Ctrl+C to stop.\n", strings.Join(siteWatcher.Paths, ", "),
+	)
+
+	return siteWatcher.Watch(ctx, func(changedPaths []string) {
+		fmt.Printf("\nDetected changes in %d file(s), rebuilding...\n", len(changedPaths))
+
+		if slices.Contains(changedPaths, opts.configPath) {
+			reloadedCfg, reloadedOpts, err := loadConfig(name, args)
+			if err == nil {
+				err = checkReload(name, cfg, reloadedCfg)
+			}
+
+			if err != nil {
+				reportError(fmt.Errorf("reloading config failed, keeping the previous one: %v", err))
+			} else {
+				cfg, opts = reloadedCfg, reloadedOpts
+				siteWatcher.Paths = watchedPaths(cfg, opts)
+			}
+		}
+
+		reportError(build(cfg, false))
+	})
+}
+
+// checkReload fails if reloadedCfg changes settings that command name can't change while it runs:
+// serve keeps serving the destination directory on the port it was started with.
+func checkReload(name string, cfg, reloadedCfg *config.Config) error {
+	if name != "serve" {
+		return nil
+	}
+
+	if reloadedCfg.ServerPort != cfg.ServerPort || reloadedCfg.DestinationDir != cfg.DestinationDir {
+		return errors.New(
+			"serverPort and destinationDir can't change while serving, restart serve to change them",
+		)
+	}
+
+	return nil
+}
+
+func watchedPaths(cfg *config.Config, opts *options) []string {
+	paths := []string{
+		cfg.ContentDir, cfg.DataDir, cfg.StaticDir, cfg.LayoutsDir, opts.configPath,
+	}
+
+	if cfg.TemplatePath != "" {
+		paths = append(paths, cfg.TemplatePath)
+	}
+
+	for _, code := range cfg.LanguageCodes() {
+		if contentDir := cfg.Languages[code].ContentDir; contentDir != "" {
+			paths = append(paths, contentDir)
+		}
+	}
+
+	return paths
+}
+
+func reportError(err error) {
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+	}
+}
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"testing"
+
+	"static-site-generator/pkg/config"
+)
+
+func TestCheckReload(t *testing.T) {
+	tests := []struct {
+		name    string
+		command string
+		change  func(cfg *config.Config)
+		wantErr bool
+	}{
+		{
+			name:    "shouldAcceptChangedTitle",
+			command: "serve",
+			change:  func(cfg *config.Config) { cfg.Title = "Changed" },
+		},
+		{
+			name:    "shouldRejectChangedPortWhileServing",
+			command: "serve",
+			change:  func(cfg *config.Config) { cfg.ServerPort = 9999 },
+			wantErr: true,
+		},
+		{
+			name:    "shouldRejectChangedDestinationDirWhileServing",
+			command: "serve",
+			change:  func(cfg *config.Config) { cfg.DestinationDir = "./dist" },
+			wantErr: true,
+		},
+		{
+			name:    "shouldAcceptChangedDestinationDirOfBuild",
+			command: "build",
+			change:  func(cfg *config.Config) { cfg.DestinationDir = "./dist" },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reloadedCfg := config.Default()
+			tt.change(reloadedCfg)
+
+			err := checkReload(tt.command, config.Default(), reloadedCfg)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("checkReload() error = %v, want error %t", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -1,31 +1,79 @@
 package main
 
 import (
-	"static-site-generator/pkg/fileutils"
-	"static-site-generator/pkg/generator"
-	"static-site-generator/pkg/server"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
 )
 
-const (
-	staticDir      = "./static"
-	contentDir     = "./content"
-	destinationDir = "./public"
-
-	templatePath = "./template.html"
+type command struct {
+	name        string
+	description string
+	run         func(args []string) error
+}
 
-	serverPort = ":8888"
-)
+var commands = []command{
+	{"build", "Generate pages into the destination directory and exit", runBuild},
+	{"serve", "Generate pages, serve the destination directory and rebuild on changes", runServe},
+	{"clean", "Delete contents of the destination directory", runClean},
+	{"new", "Create a new Markdown page in the content directory", runNew},
+}
 
 func main() {
-	panicIfError(fileutils.CleanAndCopyFromSourceDirToDestinationDir(staticDir, destinationDir))
+	os.Exit(run(os.Args[1:]))
+}
+
+// run runs the command named by the first of args with the rest of them as its flags and returns
+// exit code of the program: 1 if the command failed and 2 if there is no such command.
+func run(args []string) int {
+	if len(args) < 1 {
+		printUsage()
+		return 2
+	}
+
+	name := args[0]
+
+	if name == "help" || name == "-h" || name == "-help" || name == "--help" {
+		printUsage()
+		return 0
+	}
 
-	panicIfError(generator.GeneratePagesRecursive(contentDir, templatePath, destinationDir))
+	for _, cmd := range commands {
+		if cmd.name != name {
+			continue
+		}
 
-	panicIfError(server.ServeDir(destinationDir, serverPort))
+		err := cmd.run(args[1:])
+		if errors.Is(err, flag.ErrHelp) {
+			return 0
+		}
+
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return 1
+		}
+
+		return 0
+	}
+
+	fmt.Fprintf(os.Stderr, "Unknown command: %s\n\n", name)
+	printUsage()
+
+	return 2
 }
 
-func panicIfError(err error) {
-	if err != nil {
-		panic(err)
+func printUsage() {
+	fmt.Fprintf(os.Stderr, "Usage: %s <command> [flags]\n\nCommands:\n", programName())
+
+	for _, cmd := range commands {
+		fmt.Fprintf(os.Stderr, "  %-8s %s\n", cmd.name, cmd.description)
 	}
+
+	fmt.Fprintf(os.Stderr, "\nRun '%s <command> -h' to see flags of a command.\n", programName())
+}
+
+func programName() string {
+	return filepath.Base(os.Args[0])
 }
@@ -0,0 +1,85 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestRun(t *testing.T) {
+	dir := t.TempDir()
+	contentDir := filepath.Join(dir, "content")
+	destinationDir := filepath.Join(dir, "public")
+	configPath := filepath.Join(dir, "config.json")
+
+	config := `{"contentDir": "` + filepath.ToSlash(contentDir) + `", "destinationDir": "` +
+		filepath.ToSlash(destinationDir) + `", "manifestPath": "` +
+		filepath.ToSlash(filepath.Join(dir, ".build-manifest.json")) + `"}`
+	if err := os.WriteFile(configPath, []byte(config), 0o644); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+
+	tests := []struct {
+		name      string
+		args      []string
+		want      int
+		wantFile  string
+		wantNoDir string
+	}{
+		{name: "shouldFailWithoutCommand", args: []string{}, want: 2},
+		{name: "shouldPrintUsageForHelp", args: []string{"help"}, want: 0},
+		{name: "shouldFailForUnknownCommand", args: []string{"deploy"}, want: 2},
+		{name: "shouldPrintFlagsOfCommand", args: []string{"build", "-h"}, want: 0},
+		{name: "shouldFailForUnknownFlag", args: []string{"build", "-unknown"}, want: 1},
+		{
+			name:     "shouldCreatePageWithNew",
+			args:     []string{"new", "-config", configPath, "blog/post"},
+			want:     0,
+			wantFile: filepath.Join(contentDir, "blog", "post.md"),
+		},
+		{
+			name: "shouldFailForNewWithoutPath",
+			args: []string{"new", "-config", configPath},
+			want: 1,
+		},
+		{
+			name: "shouldFailForNewPageOutsideContentDir",
+			args: []string{"new", "-config", configPath, "../outside"},
+			want: 1,
+		},
+		{
+			name:      "shouldDeleteDestinationDirWithClean",
+			args:      []string{"clean", "-config", configPath},
+			want:      0,
+			wantNoDir: filepath.Join(destinationDir, "old"),
+		},
+	}
+
+	if err := os.MkdirAll(filepath.Join(destinationDir, "old"), 0o755); err != nil {
+		t.Fatalf("MkdirAll() error = %v", err)
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := run(tt.args); got != tt.want {
+				t.Errorf("run(%v) = %d, want %d", tt.args, got, tt.want)
+			}
+
+			if _, err := os.Stat(filepath.Join(dir, "outside.md")); err == nil {
+				t.Errorf("run(%v) created a page outside of content directory", tt.args)
+			}
+
+			if tt.wantFile != "" {
+				if _, err := os.Stat(tt.wantFile); err != nil {
+					t.Errorf("run(%v) didn't create (%s): %v", tt.args, tt.wantFile, err)
+				}
+			}
+
+			if tt.wantNoDir != "" {
+				if _, err := os.Stat(tt.wantNoDir); err == nil {
+					t.Errorf("run(%v) didn't delete (%s)", tt.args, tt.wantNoDir)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,435 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+const DefaultPath = "./config.json"
+
+type Config struct {
+	StaticDir      string `json:"staticDir"`
+	ContentDir     string `json:"contentDir"`
+	DataDir        string `json:"dataDir"`
+	DestinationDir string `json:"destinationDir"`
+	TemplatePath   string `json:"templatePath"`
+	LayoutsDir     string `json:"layoutsDir"`
+	ManifestPath   string `json:"manifestPath"`
+	ServerPort     int    `json:"serverPort"`
+
+	BuildDrafts  bool `json:"buildDrafts"`
+	BuildFuture  bool `json:"buildFuture"`
+	BuildExpired bool `json:"buildExpired"`
+
+	// Workers is the number of pages generated in parallel, GOMAXPROCS if zero.
+	Workers int `json:"workers"`
+
+	BaseURL      string `json:"baseURL"`
+	Title        string `json:"title"`
+	LanguageCode string `json:"languageCode"`
+	Author       string `json:"author"`
+	// Description and Image are the defaults of description and image front matter of pages, used
+	// by link previews. Image is a path relative to the root of the site or an absolute URL.
+	Description string `json:"description"`
+	Image       string `json:"image"`
+
+	// Taxonomies are front matter keys, e.g. tags, whose values group pages into terms.
+	Taxonomies []string `json:"taxonomies"`
+	// PrettyURLs generates foo/bar.md into foo/bar/index.html (URL /foo/bar/) instead of
+	// foo/bar.html.
+	PrettyURLs bool `json:"prettyURLs"`
+	// Permalinks are URL patterns of pages keyed by sections, e.g. "/:section/:year/:slug/".
+	Permalinks map[string]string `json:"permalinks"`
+
+	// Paginate is the number of pages per page of section and term list pages, zero disables
+	// pagination.
+	Paginate int `json:"paginate"`
+
+	// SummaryLength is the number of words automatic summaries of pages (their first paragraphs)
+	// are cut after, zero keeps whole paragraphs.
+	SummaryLength int `json:"summaryLength"`
+
+	// FeedLimit is the number of the newest pages RSS and Atom feeds list, zero lists all pages.
+	FeedLimit int `json:"feedLimit"`
+	// FeedFullContent puts the whole content of pages into feeds instead of their summaries.
+	FeedFullContent bool `json:"feedFullContent"`
+
+	// Menus are entries of named menus, e.g. "main", keyed by menu names. Pages can add entries
+	// with their menu front matter too.
+	Menus map[string][]MenuEntry `json:"menus"`
+
+	// DefaultLanguage is the language whose pages are published at the root of the site, it is
+	// LanguageCode if empty. Pages of other languages are published under /<code>/.
+	DefaultLanguage string `json:"defaultLanguage"`
+	// Languages make the site multilingual, they are keyed by language codes, e.g. "ka".
+	Languages map[string]Language `json:"languages"`
+	// LanguagePrefix is the URL path prefix of pages, e.g. "/ka", set by ForLanguage.
+	LanguagePrefix string `json:"-"`
+
+	// RedirectsFile also lists aliases of pages in a _redirects file of destination directory,
+	// for hosts that serve redirects from it.
+	RedirectsFile bool `json:"redirectsFile"`
+}
+
+// MenuEntry is a link of a menu. Identifier (Name by default) is what Parent of other entries of
+// the same menu refers to.
+type MenuEntry struct {
+	Name       string `json:"name"`
+	URL        string `json:"url"`
+	Identifier string `json:"identifier"`
+	Parent     string `json:"parent"`
+	Weight     int    `json:"weight"`
+}
+
+// Language is a language of a multilingual site. Its title and menus replace the site's ones for
+// its pages, if set.
+type Language struct {
+	// Name is shown by language switchers, e.g. "ქართული".
+	Name   string `json:"name"`
+	Title  string `json:"title"`
+	Weight int    `json:"weight"`
+	// ContentDir is an optional directory of content of the language only, next to the content
+	// directory, whose files don't need language suffixes.
+	ContentDir string                 `json:"contentDir"`
+	Menus      map[string][]MenuEntry `json:"menus"`
+}
+
+func Default() *Config {
+	return &Config{
+		StaticDir:      "./static",
+		ContentDir:     "./content",
+		DataDir:        "./data",
+		DestinationDir: "./public",
+		LayoutsDir:     "./layouts",
+		ManifestPath:   "./.build-manifest.json",
+		ServerPort:     8888,
+		LanguageCode:   "en",
+		Taxonomies:     []string{"tags", "categories"},
+		Paginate:       10,
+		SummaryLength:  70,
+		FeedLimit:      20,
+	}
+}
+
+func Load(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("loading config failed, couldn't read config file (%s): %w", path, err)
+	}
+
+	cfg, err := Parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("loading config failed, invalid config file (%s): %w", path, err)
+	}
+
+	return cfg, nil
+}
+
+func Parse(data []byte) (*Config, error) {
+	cfg := Default()
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+
+	if err := decoder.Decode(cfg); err != nil {
+		return nil, err
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	return cfg, nil
+}
+
+func (cfg *Config) Validate() error {
+	requiredPaths := []struct{ name, value string }{
+		{"staticDir", cfg.StaticDir},
+		{"contentDir", cfg.ContentDir},
+		{"dataDir", cfg.DataDir},
+		{"destinationDir", cfg.DestinationDir},
+		{"layoutsDir", cfg.LayoutsDir},
+		{"manifestPath", cfg.ManifestPath},
+	}
+
+	for _, requiredPath := range requiredPaths {
+		if strings.TrimSpace(requiredPath.value) == "" {
+			return fmt.Errorf("%w: %s must not be empty", ErrInvalidConfig, requiredPath.name)
+		}
+	}
+
+	if err := cfg.validateDestinationDir(); err != nil {
+		return err
+	}
+
+	if cfg.ServerPort < 1 || cfg.ServerPort > 65535 {
+		return fmt.Errorf("%w: serverPort (%d) must be between 1 and 65535", ErrInvalidConfig, cfg.ServerPort)
+	}
+
+	if cfg.Workers < 0 {
+		return fmt.Errorf("%w: workers (%d) must not be negative", ErrInvalidConfig, cfg.Workers)
+	}
+
+	if cfg.Paginate < 0 {
+		return fmt.Errorf("%w: paginate (%d) must not be negative", ErrInvalidConfig, cfg.Paginate)
+	}
+
+	if cfg.SummaryLength < 0 {
+		return fmt.Errorf(
+			"%w: summaryLength (%d) must not be negative", ErrInvalidConfig, cfg.SummaryLength,
+		)
+	}
+
+	if cfg.FeedLimit < 0 {
+		return fmt.Errorf("%w: feedLimit (%d) must not be negative", ErrInvalidConfig, cfg.FeedLimit)
+	}
+
+	isTaxonomy := map[string]bool{}
+
+	for _, taxonomy := range cfg.Taxonomies {
+		if taxonomy == "" || strings.ContainsAny(taxonomy, "/\\ ") {
+			return fmt.Errorf(
+				"%w: taxonomy (%s) must be a non-empty name without slashes and spaces",
+				ErrInvalidConfig, taxonomy,
+			)
+		}
+
+		if isTaxonomy[taxonomy] {
+			return fmt.Errorf("%w: taxonomy (%s) is listed twice", ErrInvalidConfig, taxonomy)
+		}
+
+		isTaxonomy[taxonomy] = true
+	}
+
+	for section, pattern := range cfg.Permalinks {
+		if err := validatePermalink(pattern); err != nil {
+			return fmt.Errorf("%w: permalink of section (%s) %v", ErrInvalidConfig, section, err)
+		}
+	}
+
+	for menu, entries := range cfg.Menus {
+		for _, entry := range entries {
+			if strings.TrimSpace(entry.Name) == "" || strings.TrimSpace(entry.URL) == "" {
+				return fmt.Errorf(
+					"%w: entries of menu (%s) must have name and url", ErrInvalidConfig, menu,
+				)
+			}
+		}
+	}
+
+	if err := cfg.validateLanguages(); err != nil {
+		return err
+	}
+
+	if cfg.BaseURL != "" {
+		baseURL, err := url.Parse(cfg.BaseURL)
+		if err != nil || baseURL.Scheme == "" || baseURL.Host == "" {
+			return fmt.Errorf("%w: baseURL (%s) must be an absolute URL", ErrInvalidConfig, cfg.BaseURL)
+		}
+
+		cfg.BaseURL = strings.TrimSuffix(cfg.BaseURL, "/")
+	}
+
+	return nil
+}
+
+// validateDestinationDir checks that destination directory, which clean and the first build
+// empty, is neither one of the source directories nor contains one of them.
+func (cfg *Config) validateDestinationDir() error {
+	destinationDir, err := filepath.Abs(cfg.DestinationDir)
+	if err != nil {
+		return fmt.Errorf("%w: destinationDir (%s): %v", ErrInvalidConfig, cfg.DestinationDir, err)
+	}
+
+	sourceDirs := []struct{ name, value string }{
+		{"contentDir", cfg.ContentDir},
+		{"staticDir", cfg.StaticDir},
+		{"layoutsDir", cfg.LayoutsDir},
+		{"dataDir", cfg.DataDir},
+	}
+
+	for _, code := range slices.Sorted(maps.Keys(cfg.Languages)) {
+		if contentDir := cfg.Languages[code].ContentDir; contentDir != "" {
+			sourceDirs = append(sourceDirs, struct{ name, value string }{
+				"contentDir of language (" + code + ")", contentDir,
+			})
+		}
+	}
+
+	for _, sourceDir := range sourceDirs {
+		dir, err := filepath.Abs(sourceDir.value)
+		if err != nil {
+			return fmt.Errorf("%w: %s (%s): %v", ErrInvalidConfig, sourceDir.name, sourceDir.value, err)
+		}
+
+		if IsInsideDir(dir, destinationDir) {
+			return fmt.Errorf(
+				"%w: destinationDir (%s) must not be or contain %s (%s), it is emptied by builds",
+				ErrInvalidConfig, cfg.DestinationDir, sourceDir.name, sourceDir.value,
+			)
+		}
+	}
+
+	return nil
+}
+
+// validateLanguages checks languages of multilingual site and defaults DefaultLanguage to
+// LanguageCode.
+func (cfg *Config) validateLanguages() error {
+	if cfg.DefaultLanguage == "" {
+		cfg.DefaultLanguage = cfg.LanguageCode
+	}
+
+	if len(cfg.Languages) == 0 {
+		return nil
+	}
+
+	if _, found := cfg.Languages[cfg.DefaultLanguage]; !found {
+		return fmt.Errorf(
+			"%w: defaultLanguage (%s) must be one of languages", ErrInvalidConfig, cfg.DefaultLanguage,
+		)
+	}
+
+	contentDirs := []string{cfg.ContentDir}
+
+	for code, language := range cfg.Languages {
+		if code == "" || strings.ContainsAny(code, "/\\. ") {
+			return fmt.Errorf(
+				"%w: language code (%s) must be a non-empty name without dots, slashes and spaces",
+				ErrInvalidConfig, code,
+			)
+		}
+
+		if language.ContentDir != "" {
+			contentDirs = append(contentDirs, language.ContentDir)
+		}
+
+		for menu, entries := range language.Menus {
+			for _, entry := range entries {
+				if strings.TrimSpace(entry.Name) == "" || strings.TrimSpace(entry.URL) == "" {
+					return fmt.Errorf(
+						"%w: entries of menu (%s) of language (%s) must have name and url",
+						ErrInvalidConfig, menu, code,
+					)
+				}
+			}
+		}
+	}
+
+	for i, dir := range contentDirs {
+		for _, otherDir := range contentDirs[i+1:] {
+			if IsInsideDir(dir, otherDir) || IsInsideDir(otherDir, dir) {
+				return fmt.Errorf(
+					"%w: content directories (%s) and (%s) of languages must not contain each other",
+					ErrInvalidConfig, dir, otherDir,
+				)
+			}
+		}
+	}
+
+	return nil
+}
+
+// IsMultilingual reports whether the site has languages.
+func (cfg *Config) IsMultilingual() bool {
+	return len(cfg.Languages) != 0
+}
+
+// LanguageCodes returns codes of the site's languages sorted by their weight (languages without
+// weight last) and code, the default language first. Single language site has only LanguageCode.
+func (cfg *Config) LanguageCodes() []string {
+	if !cfg.IsMultilingual() {
+		return []string{cfg.LanguageCode}
+	}
+
+	codes := slices.Sorted(maps.Keys(cfg.Languages))
+
+	slices.SortStableFunc(codes, func(a, b string) int {
+		weightA, weightB := cfg.Languages[a].Weight, cfg.Languages[b].Weight
+
+		switch {
+		case a == cfg.DefaultLanguage:
+			return -1
+		case b == cfg.DefaultLanguage:
+			return 1
+		case weightA == weightB:
+			return 0
+		case weightA == 0:
+			return 1
+		case weightB == 0:
+			return -1
+		default:
+			return weightA - weightB
+		}
+	})
+
+	return codes
+}
+
+// ForLanguage returns a copy of config for pages of language with code: with its LanguageCode,
+// title and menus, and with LanguagePrefix of its URLs, e.g. "/ka" (empty for the default
+// language).
+func (cfg *Config) ForLanguage(code string) *Config {
+	languageCfg := *cfg
+	languageCfg.LanguageCode = code
+
+	if !cfg.IsMultilingual() {
+		return &languageCfg
+	}
+
+	language := cfg.Languages[code]
+
+	if code != cfg.DefaultLanguage {
+		languageCfg.LanguagePrefix = "/" + code
+	}
+
+	if language.Title != "" {
+		languageCfg.Title = language.Title
+	}
+
+	if language.Menus != nil {
+		languageCfg.Menus = language.Menus
+	}
+
+	return &languageCfg
+}
+
+// IsInsideDir reports whether path is dir or is inside it.
+func IsInsideDir(path, dir string) bool {
+	relativePath, err := filepath.Rel(dir, path)
+	if err != nil {
+		return false
+	}
+
+	return relativePath != ".." &&
+		!strings.HasPrefix(relativePath, ".."+string(filepath.Separator)) &&
+		!filepath.IsAbs(relativePath)
+}
+
+var permalinkTokenRegexp = regexp.MustCompile(`:[a-z]+`)
+
+func validatePermalink(pattern string) error {
+	if !strings.HasPrefix(pattern, "/") ||
+		(!strings.HasSuffix(pattern, "/") && !strings.HasSuffix(pattern, ".html")) {
+		return fmt.Errorf("(%s) must start with / and end with / or .html", pattern)
+	}
+
+	for _, token := range permalinkTokenRegexp.FindAllString(pattern, -1) {
+		if !slices.Contains(PERMALINK_TOKENS, token) {
+			return fmt.Errorf(
+				"(%s) has unknown token %s, expected one of %s",
+				pattern, token, strings.Join(PERMALINK_TOKENS, ", "),
+			)
+		}
+	}
+
+	return nil
+}
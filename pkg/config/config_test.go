@@ -0,0 +1,234 @@
+package config
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name       string
+		data       string
+		wantConfig *Config
+		wantErr    error
+	}{
+		{
+			name: "shouldReturnDefaultsForEmptyObject",
+			data: "{}",
+			wantConfig: func() *Config {
+				cfg := Default()
+				cfg.DefaultLanguage = cfg.LanguageCode
+				return cfg
+			}(),
+			wantErr: nil,
+		},
+		{
+			name: "shouldOverrideDefaults",
+			data: `{
+				"contentDir": "./docs",
+				"dataDir": "./docs/data",
+				"serverPort": 3000,
+				"baseURL": "https://example.com/",
+				"title": "Example",
+				"languageCode": "ka",
+				"author": "Nodari",
+				"description": "Example site",
+				"image": "/images/cover.png",
+				"taxonomies": ["tags"],
+				"paginate": 5,
+				"summaryLength": 30,
+				"feedLimit": 5,
+				"feedFullContent": true,
+				"prettyURLs": true,
+				"permalinks": {"blog": "/:section/:year/:month/:slug/"},
+				"redirectsFile": true,
+				"menus": {"main": [{"name": "Blog", "url": "/blog/", "weight": 1}]}
+			}`,
+			wantConfig: &Config{
+				StaticDir:       "./static",
+				ContentDir:      "./docs",
+				DataDir:         "./docs/data",
+				DestinationDir:  "./public",
+				LayoutsDir:      "./layouts",
+				ManifestPath:    "./.build-manifest.json",
+				ServerPort:      3000,
+				BaseURL:         "https://example.com",
+				Title:           "Example",
+				LanguageCode:    "ka",
+				DefaultLanguage: "ka",
+				Author:          "Nodari",
+				Description:     "Example site",
+				Image:           "/images/cover.png",
+				Taxonomies:      []string{"tags"},
+				Paginate:        5,
+				SummaryLength:   30,
+				FeedLimit:       5,
+				FeedFullContent: true,
+				PrettyURLs:      true,
+				Permalinks:      map[string]string{"blog": "/:section/:year/:month/:slug/"},
+				RedirectsFile:   true,
+				Menus:           map[string][]MenuEntry{"main": {{Name: "Blog", URL: "/blog/", Weight: 1}}},
+			},
+			wantErr: nil,
+		},
+		{
+			name:       "shouldReturnErrInvalidConfigForEmptyPath",
+			data:       `{"destinationDir": " "}`,
+			wantConfig: nil,
+			wantErr:    ErrInvalidConfig,
+		},
+		{
+			name:       "shouldReturnErrInvalidConfigForDestinationDirThatIsContentDir",
+			data:       `{"destinationDir": "content/"}`,
+			wantConfig: nil,
+			wantErr:    ErrInvalidConfig,
+		},
+		{
+			name:       "shouldReturnErrInvalidConfigForDestinationDirContainingSourceDirs",
+			data:       `{"destinationDir": "."}`,
+			wantConfig: nil,
+			wantErr:    ErrInvalidConfig,
+		},
+		{
+			name:       "shouldReturnErrInvalidConfigForDestinationDirContainingStaticDir",
+			data:       `{"destinationDir": "./site", "staticDir": "./site/static"}`,
+			wantConfig: nil,
+			wantErr:    ErrInvalidConfig,
+		},
+		{
+			name:       "shouldReturnErrInvalidConfigForDestinationDirContainingLanguageContentDir",
+			data:       `{"languages": {"en": {}, "ka": {"contentDir": "./public/ka"}}}`,
+			wantConfig: nil,
+			wantErr:    ErrInvalidConfig,
+		},
+		{
+			name:       "shouldReturnErrInvalidConfigForInvalidPort",
+			data:       `{"serverPort": 70000}`,
+			wantConfig: nil,
+			wantErr:    ErrInvalidConfig,
+		},
+		{
+			name:       "shouldReturnErrInvalidConfigForNegativeSummaryLength",
+			data:       `{"summaryLength": -1}`,
+			wantConfig: nil,
+			wantErr:    ErrInvalidConfig,
+		},
+		{
+			name:       "shouldReturnErrInvalidConfigForNegativeFeedLimit",
+			data:       `{"feedLimit": -1}`,
+			wantConfig: nil,
+			wantErr:    ErrInvalidConfig,
+		},
+		{
+			name:       "shouldReturnErrInvalidConfigForDuplicateTaxonomy",
+			data:       `{"taxonomies": ["tags", "tags"]}`,
+			wantConfig: nil,
+			wantErr:    ErrInvalidConfig,
+		},
+		{
+			name:       "shouldReturnErrInvalidConfigForUnknownPermalinkToken",
+			data:       `{"permalinks": {"blog": "/:section/:author/"}}`,
+			wantConfig: nil,
+			wantErr:    ErrInvalidConfig,
+		},
+		{
+			name:       "shouldReturnErrInvalidConfigForRelativePermalink",
+			data:       `{"permalinks": {"blog": ":section/:slug/"}}`,
+			wantConfig: nil,
+			wantErr:    ErrInvalidConfig,
+		},
+		{
+			name:       "shouldReturnErrInvalidConfigForMenuEntryWithoutURL",
+			data:       `{"menus": {"main": [{"name": "Blog"}]}}`,
+			wantConfig: nil,
+			wantErr:    ErrInvalidConfig,
+		},
+		{
+			name:       "shouldReturnErrInvalidConfigForMissingDefaultLanguage",
+			data:       `{"defaultLanguage": "de", "languages": {"en": {}, "ka": {}}}`,
+			wantConfig: nil,
+			wantErr:    ErrInvalidConfig,
+		},
+		{
+			name:       "shouldReturnErrInvalidConfigForInvalidLanguageCode",
+			data:       `{"languages": {"en": {}, "k/a": {}}}`,
+			wantConfig: nil,
+			wantErr:    ErrInvalidConfig,
+		},
+		{
+			name:       "shouldReturnErrInvalidConfigForLanguageContentDirInsideContentDir",
+			data:       `{"languages": {"en": {}, "ka": {"contentDir": "./content/ka"}}}`,
+			wantConfig: nil,
+			wantErr:    ErrInvalidConfig,
+		},
+		{
+			name:       "shouldReturnErrInvalidConfigForRelativeBaseURL",
+			data:       `{"baseURL": "/blog"}`,
+			wantConfig: nil,
+			wantErr:    ErrInvalidConfig,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotConfig, gotErr := Parse([]byte(tt.data))
+			if !reflect.DeepEqual(gotConfig, tt.wantConfig) || !errors.Is(gotErr, tt.wantErr) {
+				t.Errorf(
+					"Parse() = (%+v, %v), want (%+v, %v)",
+					gotConfig, gotErr, tt.wantConfig, tt.wantErr,
+				)
+			}
+		})
+	}
+}
+
+func TestParse_UnknownField(t *testing.T) {
+	if _, err := Parse([]byte(`{"contentDirectory": "./docs"}`)); err == nil {
+		t.Errorf("Parse() = (_, nil), want (_, error) for unknown field")
+	}
+}
+
+func TestConfig_ForLanguage(t *testing.T) {
+	cfg, err := Parse([]byte(`{
+		"title": "Example",
+		"menus": {"main": [{"name": "Blog", "url": "/blog/"}]},
+		"languages": {
+			"en": {"name": "English"},
+			"ka": {"name": "ქართული", "title": "მაგალითი", "weight": 2, "menus": {}},
+			"de": {"name": "Deutsch", "weight": 1}
+		}
+	}`))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	if got, want := cfg.LanguageCodes(), []string{"en", "de", "ka"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("LanguageCodes() = %v, want %v", got, want)
+	}
+
+	tests := []struct {
+		code       string
+		wantPrefix string
+		wantTitle  string
+		wantMenus  int
+	}{
+		{code: "en", wantPrefix: "", wantTitle: "Example", wantMenus: 1},
+		{code: "ka", wantPrefix: "/ka", wantTitle: "მაგალითი", wantMenus: 0},
+		{code: "de", wantPrefix: "/de", wantTitle: "Example", wantMenus: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.code, func(t *testing.T) {
+			got := cfg.ForLanguage(tt.code)
+			if got.LanguageCode != tt.code || got.LanguagePrefix != tt.wantPrefix ||
+				got.Title != tt.wantTitle || len(got.Menus) != tt.wantMenus {
+				t.Errorf(
+					"ForLanguage() = (%s, %q, %s, %d menu(s)), want (%s, %q, %s, %d menu(s))",
+					got.LanguageCode, got.LanguagePrefix, got.Title, len(got.Menus),
+					tt.code, tt.wantPrefix, tt.wantTitle, tt.wantMenus,
+				)
+			}
+		})
+	}
+}
@@ -0,0 +1,10 @@
+package config
+
+import "errors"
+
+// PERMALINK_TOKENS are tokens permalink patterns can use.
+var PERMALINK_TOKENS = []string{
+	":year", ":month", ":day", ":section", ":sections", ":slug", ":filename", ":title",
+}
+
+var ErrInvalidConfig = errors.New("invalid config")
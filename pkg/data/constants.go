@@ -0,0 +1,10 @@
+package data
+
+import "errors"
+
+const (
+	JSON_EXTENSION = ".json"
+	CSV_EXTENSION  = ".csv"
+)
+
+var ErrInvalidDataFile = errors.New("invalid data file")
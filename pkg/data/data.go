@@ -0,0 +1,168 @@
+package data
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Load parses JSON and CSV files of dataDir into a map keyed by file names without extensions,
+// e.g. data/team.json becomes key "team". Subdirectories become nested maps, so that
+// data/releases/v1.json is at "releases" > "v1". Other files are ignored and a missing dataDir is
+// not an error. Errors of malformed files name the file and the line.
+func Load(dataDir string) (map[string]any, error) {
+	siteData := map[string]any{}
+
+	if _, err := os.Stat(dataDir); errors.Is(err, fs.ErrNotExist) {
+		return siteData, nil
+	}
+
+	// isDir is keyed by slash separated paths of nested maps of directories.
+	isDir := map[string]bool{}
+
+	handleWalkDirEntry := func(path string, entry os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		extension := filepath.Ext(path)
+		if entry.IsDir() || (extension != JSON_EXTENSION && extension != CSV_EXTENSION) {
+			return nil
+		}
+
+		source, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("couldn't read data file (%s): %v", path, err)
+		}
+
+		var value any
+		if extension == JSON_EXTENSION {
+			value, err = ParseJSON(source)
+		} else {
+			value, err = ParseCSV(source)
+		}
+
+		if err != nil {
+			return fmt.Errorf("%w (%s): %v", ErrInvalidDataFile, path, err)
+		}
+
+		relativePath, err := filepath.Rel(dataDir, path)
+		if err != nil {
+			return err
+		}
+
+		keys := strings.Split(filepath.ToSlash(strings.TrimSuffix(relativePath, extension)), "/")
+		current := siteData
+
+		for i, key := range keys[:len(keys)-1] {
+			dirPath := strings.Join(keys[:i+1], "/")
+
+			if _, found := current[key]; !found {
+				current[key] = map[string]any{}
+				isDir[dirPath] = true
+			}
+
+			if !isDir[dirPath] {
+				return fmt.Errorf(
+					"%w (%s): directory (%s) has the name of a data file",
+					ErrInvalidDataFile, path, dirPath,
+				)
+			}
+
+			current = current[key].(map[string]any)
+		}
+
+		if _, found := current[keys[len(keys)-1]]; found {
+			return fmt.Errorf(
+				"%w (%s): another data file or directory has the same name",
+				ErrInvalidDataFile, path,
+			)
+		}
+
+		current[keys[len(keys)-1]] = value
+
+		return nil
+	}
+
+	if err := filepath.WalkDir(dataDir, handleWalkDirEntry); err != nil {
+		return nil, fmt.Errorf("loading data failed: %w", err)
+	}
+
+	return siteData, nil
+}
+
+// ParseJSON parses a JSON document. Errors report the line of the malformed value.
+func ParseJSON(source []byte) (any, error) {
+	var value any
+
+	decoder := json.NewDecoder(bytes.NewReader(source))
+	err := decoder.Decode(&value)
+	if err == nil {
+		if _, extraErr := decoder.Token(); extraErr != io.EOF {
+			return nil, fmt.Errorf(
+				"line %d: unexpected data after the top-level value",
+				lineAt(source, decoder.InputOffset()),
+			)
+		}
+
+		return value, nil
+	}
+
+	var syntaxErr *json.SyntaxError
+	if errors.As(err, &syntaxErr) {
+		return nil, fmt.Errorf("line %d: %v", lineAt(source, syntaxErr.Offset), err)
+	}
+
+	if errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf(
+			"line %d: unexpected end of JSON input", lineAt(source, int64(len(source))),
+		)
+	}
+
+	return nil, err
+}
+
+// ParseCSV parses CSV with a header row into a list of rows keyed by header's column names.
+// Errors report the line of the malformed row.
+func ParseCSV(source []byte) ([]map[string]string, error) {
+	reader := csv.NewReader(bytes.NewReader(source))
+
+	records, err := reader.ReadAll()
+	if err != nil {
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) {
+			return nil, fmt.Errorf("line %d: %v", parseErr.Line, parseErr.Err)
+		}
+
+		return nil, err
+	}
+
+	rows := []map[string]string{}
+	if len(records) == 0 {
+		return rows, nil
+	}
+
+	header := records[0]
+	for _, record := range records[1:] {
+		row := map[string]string{}
+		for i, column := range header {
+			row[strings.TrimSpace(column)] = record[i]
+		}
+
+		rows = append(rows, row)
+	}
+
+	return rows, nil
+}
+
+func lineAt(source []byte, offset int64) int {
+	offset = min(max(offset, 0), int64(len(source)))
+	return bytes.Count(source[:offset], []byte("\n")) + 1
+}
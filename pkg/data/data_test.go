@@ -0,0 +1,111 @@
+package data
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseJSON(t *testing.T) {
+	tests := []struct {
+		name        string
+		source      string
+		want        any
+		wantErrLine string
+	}{
+		{
+			name:   "shouldParseObject",
+			source: `{"name": "Frodo", "age": 50, "tags": ["hobbit"]}`,
+			want:   map[string]any{"name": "Frodo", "age": 50.0, "tags": []any{"hobbit"}},
+		},
+		{
+			name:   "shouldParseList",
+			source: "[\n  {\"version\": \"1.0\"}\n]\n",
+			want:   []any{map[string]any{"version": "1.0"}},
+		},
+		{
+			name:        "shouldReportLineOfSyntaxError",
+			source:      "{\n  \"name\": \"Frodo\",\n  \"age\": 50,\n}\n",
+			wantErrLine: "line 4:",
+		},
+		{
+			name:        "shouldReportLineOfUnexpectedEnd",
+			source:      "[\n  1,\n  2\n",
+			wantErrLine: "line 4:",
+		},
+		{
+			name:        "shouldReportLineOfExtraData",
+			source:      "{}\n{}\n",
+			wantErrLine: "line 2:",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseJSON([]byte(tt.source))
+
+			if tt.wantErrLine != "" {
+				if err == nil || !strings.HasPrefix(err.Error(), tt.wantErrLine) {
+					t.Fatalf("ParseJSON() error = %v, want error starting with %q", err, tt.wantErrLine)
+				}
+
+				return
+			}
+
+			if err != nil || !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseJSON() = (%v, %v), want (%v, nil)", got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseCSV(t *testing.T) {
+	tests := []struct {
+		name        string
+		source      string
+		want        []map[string]string
+		wantErrLine string
+	}{
+		{
+			name:   "shouldKeyRowsByHeader",
+			source: "version, date\n1.0,2024-01-02\n1.1,\"2024-03-04\"\n",
+			want: []map[string]string{
+				{"version": "1.0", "date": "2024-01-02"},
+				{"version": "1.1", "date": "2024-03-04"},
+			},
+		},
+		{
+			name:   "shouldParseEmptyFile",
+			source: "",
+			want:   []map[string]string{},
+		},
+		{
+			name:        "shouldReportLineOfRowWithWrongNumberOfFields",
+			source:      "version,date\n1.0,2024-01-02\n1.1\n",
+			wantErrLine: "line 3:",
+		},
+		{
+			name:        "shouldReportLineOfBareQuote",
+			source:      "name\n\"Frodo\n",
+			wantErrLine: "line 2:",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseCSV([]byte(tt.source))
+
+			if tt.wantErrLine != "" {
+				if err == nil || !strings.HasPrefix(err.Error(), tt.wantErrLine) {
+					t.Fatalf("ParseCSV() error = %v, want error starting with %q", err, tt.wantErrLine)
+				}
+
+				return
+			}
+
+			if err != nil || !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseCSV() = (%v, %v), want (%v, nil)", got, err, tt.want)
+			}
+		})
+	}
+}
@@ -3,18 +3,15 @@ package fileutils
 import (
 	"errors"
 	"fmt"
+	"io"
 	"io/fs"
 	"os"
 	"path"
+	"path/filepath"
 )
 
-func CleanAndCopyFromSourceDirToDestinationDir(sourceDir, destinationDir string) error {
-	err := deleteContentsOfDestinationDir(destinationDir)
-	if err != nil {
-		return err
-	}
-
-	return copyFromSourceDirToDestinationDir(sourceDir, destinationDir)
+func CleanDestinationDir(destinationDir string) error {
+	return deleteContentsOfDestinationDir(destinationDir)
 }
 
 func deleteContentsOfDestinationDir(destinationDir string) error {
@@ -57,24 +54,61 @@ func createDestinationDir(destinationDir string) error {
 	return nil
 }
 
-func copyFromSourceDirToDestinationDir(sourceDir, destinationDir string) error {
-	fmt.Printf(
-		"Copying contents of source directory (%s) into destination directory (%s)...\n",
-		sourceDir, destinationDir,
-	)
+func CopyFile(sourcePath, destinationPath string) error {
+	sourceFile, err := os.Open(sourcePath)
+	if err != nil {
+		return fmt.Errorf("copying file (%s) failed: %v", sourcePath, err)
+	}
+
+	defer sourceFile.Close()
 
-	err := os.CopyFS(destinationDir, os.DirFS(sourceDir+"/"))
+	err = os.MkdirAll(filepath.Dir(destinationPath), 0o755)
 	if err != nil {
 		return fmt.Errorf(
-			"copying contents of source directory (%s) into destination directory (%s) failed: %v",
-			sourceDir, destinationDir, err,
+			"copying file (%s) failed, couldn't create destination file's directory (%s): %v",
+			sourcePath, filepath.Dir(destinationPath), err,
+		)
+	}
+
+	destinationFile, err := os.Create(destinationPath)
+	if err != nil {
+		return fmt.Errorf(
+			"copying file (%s) failed, couldn't create destination file (%s): %v",
+			sourcePath, destinationPath, err,
+		)
+	}
+
+	defer destinationFile.Close()
+
+	if _, err := io.Copy(destinationFile, sourceFile); err != nil {
+		return fmt.Errorf(
+			"copying file (%s) to destination file (%s) failed: %v", sourcePath, destinationPath, err,
 		)
 	}
 
-	fmt.Printf(
-		"Copied contents of source directory (%s) into destination directory (%s) successfully!\n",
-		sourceDir, destinationDir,
-	)
+	return nil
+}
+
+// RemoveFileAndEmptyParentDirs removes the file at filePath and then every parent directory that
+// became empty, stopping at rootDir.
+func RemoveFileAndEmptyParentDirs(filePath, rootDir string) error {
+	err := os.Remove(filePath)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("removing file (%s) failed: %v", filePath, err)
+	}
+
+	rootDir = filepath.Clean(rootDir)
+
+	for dir := filepath.Dir(filePath); dir != rootDir && dir != "." && dir != "/"; dir = filepath.Dir(dir) {
+		dirEntries, err := os.ReadDir(dir)
+		if err != nil || len(dirEntries) != 0 {
+			return nil
+		}
+
+		if err := os.Remove(dir); err != nil {
+			return nil
+		}
+	}
 
 	return nil
 }
@@ -0,0 +1,14 @@
+package frontmatter
+
+import "errors"
+
+const (
+	YAML_DELIMITER = "---"
+	TOML_DELIMITER = "+++"
+)
+
+var (
+	ErrUnterminatedFrontMatter = errors.New("front matter is not terminated")
+	ErrInvalidFrontMatter      = errors.New("invalid front matter")
+	ErrInvalidFrontMatterField = errors.New("invalid front matter field")
+)
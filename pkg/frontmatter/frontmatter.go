@@ -0,0 +1,293 @@
+package frontmatter
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+)
+
+type FrontMatter struct {
+	Title       string
+	Date        time.Time
+	ExpiryDate  time.Time
+	Description string
+	Draft       bool
+	Layout      string
+	Slug        string
+	Tags        []string
+	Weight      int
+
+	// Params holds every front matter key, including the ones that are mapped to typed fields.
+	Params map[string]any
+
+	// texts holds raw texts of top level keys of parsed front matter, so that string fields keep
+	// values like 010, 1e3 or 2024-05-01 10:00 as they are written, see scalarText.
+	texts map[string]any
+}
+
+// Parse splits source into front matter and Markdown body. Front matter must start on the first
+// line and be delimited by --- (YAML) or +++ (TOML) lines. Source without front matter is
+// returned unchanged with empty front matter.
+func Parse(source string) (*FrontMatter, string, error) {
+	source = strings.TrimPrefix(source, "\uFEFF")
+
+	firstLine, rest, _ := strings.Cut(source, "\n")
+	delimiter := strings.TrimRight(firstLine, " \t\r")
+
+	if delimiter != YAML_DELIMITER && delimiter != TOML_DELIMITER {
+		return &FrontMatter{Params: map[string]any{}}, source, nil
+	}
+
+	block, body, found := cutAtDelimiterLine(rest, delimiter)
+	if !found {
+		return nil, "", fmt.Errorf("%w: missing closing %s", ErrUnterminatedFrontMatter, delimiter)
+	}
+
+	var params, texts map[string]any
+	var err error
+
+	if delimiter == YAML_DELIMITER {
+		params, texts, err = parseYAML(block, 2)
+	} else {
+		params, texts, err = parseTOML(block, 2)
+	}
+
+	if err != nil {
+		return nil, "", err
+	}
+
+	frontMatter, err := newFrontMatter(params, texts)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return frontMatter, body, nil
+}
+
+func cutAtDelimiterLine(text, delimiter string) (string, string, bool) {
+	offset := 0
+
+	for offset <= len(text) {
+		line, _, _ := strings.Cut(text[offset:], "\n")
+		lineEnd := offset + len(line)
+
+		if strings.TrimRight(line, " \t\r") == delimiter {
+			body := ""
+			if lineEnd < len(text) {
+				body = text[lineEnd+1:]
+			}
+
+			return text[:offset], body, true
+		}
+
+		offset = lineEnd + 1
+	}
+
+	return "", "", false
+}
+
+// New maps known keys of params to typed fields of FrontMatter.
+func New(params map[string]any) (*FrontMatter, error) {
+	return newFrontMatter(params, nil)
+}
+
+// newFrontMatter is New for parsed front matter with raw texts of its values.
+func newFrontMatter(params, texts map[string]any) (*FrontMatter, error) {
+	frontMatter := &FrontMatter{Params: params, texts: texts}
+	var err error
+
+	if frontMatter.Title, err = getString(params, texts, "title"); err != nil {
+		return nil, err
+	}
+
+	if frontMatter.Date, err = getDate(params, "date"); err != nil {
+		return nil, err
+	}
+
+	if frontMatter.ExpiryDate, err = getDate(params, "expiryDate"); err != nil {
+		return nil, err
+	}
+
+	if frontMatter.Description, err = getString(params, texts, "description"); err != nil {
+		return nil, err
+	}
+
+	if frontMatter.Draft, err = getBool(params, "draft"); err != nil {
+		return nil, err
+	}
+
+	if frontMatter.Layout, err = getString(params, texts, "layout"); err != nil {
+		return nil, err
+	}
+
+	if frontMatter.Slug, err = getString(params, texts, "slug"); err != nil {
+		return nil, err
+	}
+
+	if frontMatter.Tags, err = getStrings(params, texts, "tags"); err != nil {
+		return nil, err
+	}
+
+	if frontMatter.Weight, err = getInt(params, "weight"); err != nil {
+		return nil, err
+	}
+
+	return frontMatter, nil
+}
+
+// String returns value of front matter key as a string, empty if the key is missing.
+func (frontMatter *FrontMatter) String(key string) (string, error) {
+	return getString(frontMatter.Params, frontMatter.texts, key)
+}
+
+// Strings returns value of front matter key as a list of strings, e.g. for taxonomies. A single
+// string becomes a list with one item.
+func (frontMatter *FrontMatter) Strings(key string) ([]string, error) {
+	return getStrings(frontMatter.Params, frontMatter.texts, key)
+}
+
+// Int returns value of front matter key as an integer, zero if the key is missing.
+func (frontMatter *FrontMatter) Int(key string) (int, error) {
+	return getInt(frontMatter.Params, key)
+}
+
+// Float returns value of front matter key as a number, zero if the key is missing.
+func (frontMatter *FrontMatter) Float(key string) (float64, error) {
+	return getFloat(frontMatter.Params, key)
+}
+
+// Bool returns value of front matter key as a boolean, false if the key is missing.
+func (frontMatter *FrontMatter) Bool(key string) (bool, error) {
+	return getBool(frontMatter.Params, key)
+}
+
+// Time returns value of front matter key as a date, zero if the key is missing.
+func (frontMatter *FrontMatter) Time(key string) (time.Time, error) {
+	return getDate(frontMatter.Params, key)
+}
+
+func fieldError(key string, value any, expected string) error {
+	return fmt.Errorf(
+		"%w: %s must be %s, got %v (%T)", ErrInvalidFrontMatterField, key, expected, value, value,
+	)
+}
+
+// getString returns value of key as a string. Numbers, booleans and dates are returned as they
+// are written in texts, or formatted if there is no text, e.g. for front matter set by New.
+func getString(params, texts map[string]any, key string) (string, error) {
+	if text, ok := texts[key].(string); ok && isScalar(params[key]) {
+		return text, nil
+	}
+
+	switch value := params[key].(type) {
+	case nil:
+		return "", nil
+	case string:
+		return value, nil
+	case int, float64, bool:
+		return fmt.Sprint(value), nil
+	case time.Time:
+		return formatDate(value), nil
+	default:
+		return "", fieldError(key, value, "a string")
+	}
+}
+
+func getBool(params map[string]any, key string) (bool, error) {
+	switch value := params[key].(type) {
+	case nil:
+		return false, nil
+	case bool:
+		return value, nil
+	default:
+		return false, fieldError(key, value, "a boolean")
+	}
+}
+
+func getInt(params map[string]any, key string) (int, error) {
+	switch value := params[key].(type) {
+	case nil:
+		return 0, nil
+	case int:
+		return value, nil
+	case float64:
+		if value == math.Trunc(value) {
+			return int(value), nil
+		}
+	}
+
+	return 0, fieldError(key, params[key], "an integer")
+}
+
+func getFloat(params map[string]any, key string) (float64, error) {
+	switch value := params[key].(type) {
+	case nil:
+		return 0, nil
+	case int:
+		return float64(value), nil
+	case float64:
+		return value, nil
+	default:
+		return 0, fieldError(key, value, "a number")
+	}
+}
+
+func getDate(params map[string]any, key string) (time.Time, error) {
+	switch value := params[key].(type) {
+	case nil:
+		return time.Time{}, nil
+	case time.Time:
+		return value, nil
+	case string:
+		if date, ok := parseDate(value); ok {
+			return date, nil
+		}
+	}
+
+	return time.Time{}, fieldError(key, params[key], "a date")
+}
+
+func getStrings(params, texts map[string]any, key string) ([]string, error) {
+	switch value := params[key].(type) {
+	case nil:
+		return []string{}, nil
+	case string:
+		return []string{value}, nil
+	case []any:
+		result := make([]string, 0, len(value))
+		itemTexts, _ := texts[key].([]string)
+
+		for i, item := range value {
+			if _, ok := item.(map[string]any); ok {
+				return nil, fieldError(key, value, "a list of strings")
+			}
+
+			if len(itemTexts) == len(value) && isScalar(item) {
+				result = append(result, itemTexts[i])
+				continue
+			}
+
+			if date, ok := item.(time.Time); ok {
+				result = append(result, formatDate(date))
+				continue
+			}
+
+			result = append(result, fmt.Sprint(item))
+		}
+
+		return result, nil
+	default:
+		return nil, fieldError(key, value, "a list of strings")
+	}
+}
+
+// isScalar reports whether value is a number, boolean or date, whose text is kept by getString.
+func isScalar(value any) bool {
+	switch value.(type) {
+	case int, float64, bool, time.Time:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,201 @@
+package frontmatter
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name            string
+		source          string
+		wantFrontMatter *FrontMatter
+		wantBody        string
+		wantErr         error
+	}{
+		{
+			name:            "shouldReturnSourceWithoutFrontMatter",
+			source:          "# Hello\n\n---\n",
+			wantFrontMatter: &FrontMatter{Params: map[string]any{}},
+			wantBody:        "# Hello\n\n---\n",
+			wantErr:         nil,
+		},
+		{
+			name:   "shouldParseEmptyFrontMatter",
+			source: "---\n---\n# Hello",
+			wantFrontMatter: &FrontMatter{
+				Tags:   []string{},
+				Params: map[string]any{},
+			},
+			wantBody: "# Hello",
+			wantErr:  nil,
+		},
+		{
+			name:   "shouldParseYAMLFrontMatter",
+			source: "---\ntitle: Hello world\ndate: 2024-10-01\ndescription: \"Hi: there\"\ndraft: true\nlayout: post\nslug: hi\ntags: [go, ssg]\nweight: 10\nauthor: Nodari\n---\n# Hello\n",
+			wantFrontMatter: &FrontMatter{
+				Title:       "Hello world",
+				Date:        time.Date(2024, 10, 1, 0, 0, 0, 0, time.UTC),
+				Description: "Hi: there",
+				Draft:       true,
+				Layout:      "post",
+				Slug:        "hi",
+				Tags:        []string{"go", "ssg"},
+				Weight:      10,
+				Params: map[string]any{
+					"title":       "Hello world",
+					"date":        time.Date(2024, 10, 1, 0, 0, 0, 0, time.UTC),
+					"description": "Hi: there",
+					"draft":       true,
+					"layout":      "post",
+					"slug":        "hi",
+					"tags":        []any{"go", "ssg"},
+					"weight":      10,
+					"author":      "Nodari",
+				},
+			},
+			wantBody: "# Hello\n",
+			wantErr:  nil,
+		},
+		{
+			name:   "shouldParseTOMLFrontMatter",
+			source: "+++\r\ntitle = \"Hello world\"\r\ntags = [\"go\"]\r\n+++\r\n# Hello",
+			wantFrontMatter: &FrontMatter{
+				Title: "Hello world",
+				Tags:  []string{"go"},
+				Params: map[string]any{
+					"title": "Hello world",
+					"tags":  []any{"go"},
+				},
+			},
+			wantBody: "# Hello",
+			wantErr:  nil,
+		},
+		{
+			name:   "shouldKeepInfinityAndNaNWordsAsStrings",
+			source: "---\nauthor: Nan\ntags: [inf, -Infinity, .nan, 1.5e3, -.5]\n---\n",
+			wantFrontMatter: &FrontMatter{
+				Tags: []string{"inf", "-Infinity", ".nan", "1.5e3", "-.5"},
+				Params: map[string]any{
+					"author": "Nan",
+					"tags":   []any{"inf", "-Infinity", ".nan", 1500.0, -0.5},
+				},
+			},
+			wantBody: "",
+			wantErr:  nil,
+		},
+		{
+			name:   "shouldKeepDatesOfStringFieldsAsText",
+			source: "+++\ntitle = 2024-05-01\ntags = [2024-05-01T10:30:00Z]\n+++\n",
+			wantFrontMatter: &FrontMatter{
+				Title: "2024-05-01",
+				Tags:  []string{"2024-05-01T10:30:00Z"},
+				Params: map[string]any{
+					"title": time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC),
+					"tags":  []any{time.Date(2024, 5, 1, 10, 30, 0, 0, time.UTC)},
+				},
+			},
+			wantBody: "",
+			wantErr:  nil,
+		},
+		{
+			name:   "shouldKeepNumbersAndDatesOfStringFieldsAsWritten",
+			source: "---\ntitle: 2024-05-01 10:00\nslug: 010\ndescription: 1e3\ntags:\n  - 007\n  - true\n---\n",
+			wantFrontMatter: &FrontMatter{
+				Title:       "2024-05-01 10:00",
+				Description: "1e3",
+				Slug:        "010",
+				Tags:        []string{"007", "true"},
+				Params: map[string]any{
+					"title":       time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC),
+					"slug":        10,
+					"description": 1000.0,
+					"tags":        []any{7, true},
+				},
+			},
+			wantBody: "",
+			wantErr:  nil,
+		},
+		{
+			name:   "shouldKeepNumbersOfTOMLStringFieldsAsWritten",
+			source: "+++\ntitle = 010\ntags = [1e3, \"x\"]\n[extra]\nslug = 1\n+++\n",
+			wantFrontMatter: &FrontMatter{
+				Title: "010",
+				Tags:  []string{"1e3", "x"},
+				Params: map[string]any{
+					"title": 10,
+					"tags":  []any{1000.0, "x"},
+					"extra": map[string]any{"slug": 1},
+				},
+			},
+			wantBody: "",
+			wantErr:  nil,
+		},
+		{
+			name:            "shouldReturnErrUnterminatedFrontMatter",
+			source:          "---\ntitle: Hello\n# Hello",
+			wantFrontMatter: nil,
+			wantBody:        "",
+			wantErr:         ErrUnterminatedFrontMatter,
+		},
+		{
+			name:            "shouldReturnErrInvalidFrontMatterField",
+			source:          "---\ndraft: maybe\n---\n",
+			wantFrontMatter: nil,
+			wantBody:        "",
+			wantErr:         ErrInvalidFrontMatterField,
+		},
+		{
+			name:            "shouldReturnErrInvalidFrontMatter",
+			source:          "---\ntitle Hello\n---\n",
+			wantFrontMatter: nil,
+			wantBody:        "",
+			wantErr:         ErrInvalidFrontMatter,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotFrontMatter, gotBody, gotErr := Parse(tt.source)
+			// Raw texts are checked through the string fields they are kept for.
+			if gotFrontMatter != nil {
+				gotFrontMatter.texts = nil
+			}
+
+			if !reflect.DeepEqual(gotFrontMatter, tt.wantFrontMatter) ||
+				gotBody != tt.wantBody ||
+				!errors.Is(gotErr, tt.wantErr) {
+				t.Errorf(
+					"Parse() = (%+v, %q, %v), want (%+v, %q, %v)",
+					gotFrontMatter, gotBody, gotErr, tt.wantFrontMatter, tt.wantBody, tt.wantErr,
+				)
+			}
+		})
+	}
+}
+
+func TestFrontMatter_Float(t *testing.T) {
+	frontMatter := &FrontMatter{Params: map[string]any{"int": 1, "float": 0.5, "string": "high"}}
+
+	tests := []struct {
+		key     string
+		want    float64
+		wantErr error
+	}{
+		{"int", 1, nil},
+		{"float", 0.5, nil},
+		{"missing", 0, nil},
+		{"string", 0, ErrInvalidFrontMatterField},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			got, err := frontMatter.Float(tt.key)
+			if got != tt.want || !errors.Is(err, tt.wantErr) {
+				t.Errorf("Float(%s) = (%v, %v), want (%v, %v)", tt.key, got, err, tt.want, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,182 @@
+package frontmatter
+
+import (
+	"fmt"
+	"strings"
+)
+
+// parseTOML parses a subset of TOML: key/value pairs, dotted keys, [table] headers, single and
+// multi-line arrays and scalars. firstLineNumber is used to report errors with line numbers of
+// the source file. It also returns raw texts of top level values, see scalarText.
+func parseTOML(block string, firstLineNumber int) (map[string]any, map[string]any, error) {
+	result := map[string]any{}
+	texts := map[string]any{}
+	table := result
+	isTopLevel := true
+
+	lines := strings.Split(block, "\n")
+
+	for i := 0; i < len(lines); i++ {
+		lineNumber := firstLineNumber + i
+
+		errorf := func(format string, args ...any) error {
+			return fmt.Errorf(
+				"%w: line %d: %s", ErrInvalidFrontMatter, lineNumber, fmt.Sprintf(format, args...),
+			)
+		}
+
+		line := strings.TrimSpace(stripComment(lines[i]))
+		if line == "" {
+			continue
+		}
+
+		if line[0] == '[' {
+			if strings.HasPrefix(line, "[[") {
+				return nil, nil, errorf("arrays of tables are not supported")
+			}
+
+			if !strings.HasSuffix(line, "]") {
+				return nil, nil, errorf("table header %s is not closed", line)
+			}
+
+			keys, err := splitTOMLKey(line[1 : len(line)-1])
+			if err != nil {
+				return nil, nil, errorf("%v", err)
+			}
+
+			table, err = tomlTable(result, keys)
+			if err != nil {
+				return nil, nil, errorf("%v", err)
+			}
+
+			isTopLevel = false
+
+			continue
+		}
+
+		rawKey, rawValue, found := strings.Cut(line, "=")
+		if !found {
+			return nil, nil, errorf("expected \"key = value\", got %q", line)
+		}
+
+		rawValue = strings.TrimSpace(rawValue)
+
+		for strings.HasPrefix(rawValue, "[") && !isTOMLArrayClosed(rawValue) {
+			i++
+			if i >= len(lines) {
+				return nil, nil, errorf("array is not closed")
+			}
+
+			rawValue += " " + strings.TrimSpace(stripComment(lines[i]))
+		}
+
+		if strings.HasPrefix(rawValue, "{") {
+			return nil, nil, errorf("inline tables are not supported")
+		}
+
+		if strings.HasPrefix(rawValue, `"""`) || strings.HasPrefix(rawValue, "'''") {
+			return nil, nil, errorf("multi-line strings are not supported")
+		}
+
+		if rawValue == "" {
+			return nil, nil, errorf("missing value for key %s", strings.TrimSpace(rawKey))
+		}
+
+		value, err := parseScalar(rawValue)
+		if err != nil {
+			return nil, nil, errorf("%v", err)
+		}
+
+		keys, err := splitTOMLKey(rawKey)
+		if err != nil {
+			return nil, nil, errorf("%v", err)
+		}
+
+		target, err := tomlTable(table, keys[:len(keys)-1])
+		if err != nil {
+			return nil, nil, errorf("%v", err)
+		}
+
+		key := keys[len(keys)-1]
+		if _, exists := target[key]; exists {
+			return nil, nil, errorf("duplicate key %q", key)
+		}
+
+		target[key] = value
+		if isTopLevel && len(keys) == 1 {
+			texts[key] = scalarText(rawValue)
+		}
+	}
+
+	return result, texts, nil
+}
+
+func splitTOMLKey(rawKey string) ([]string, error) {
+	parts, err := splitOutsideQuotes(rawKey, '.')
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make([]string, len(parts))
+
+	for i, part := range parts {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			return nil, fmt.Errorf("invalid key %q", strings.TrimSpace(rawKey))
+		}
+
+		if part[0] == '"' || part[0] == '\'' {
+			unquotedPart, err := parseScalar(part)
+			if err != nil {
+				return nil, err
+			}
+
+			part = fmt.Sprint(unquotedPart)
+		}
+
+		keys[i] = part
+	}
+
+	return keys, nil
+}
+
+// tomlTable returns nested table of root addressed by keys, creating missing tables.
+func tomlTable(root map[string]any, keys []string) (map[string]any, error) {
+	table := root
+
+	for _, key := range keys {
+		value, exists := table[key]
+		if !exists {
+			child := map[string]any{}
+			table[key] = child
+			table = child
+			continue
+		}
+
+		child, ok := value.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("key %q is already defined as a value", key)
+		}
+
+		table = child
+	}
+
+	return table, nil
+}
+
+func isTOMLArrayClosed(rawValue string) bool {
+	depth := 0
+
+	closed := scanOutsideQuotes(rawValue, func(i int) bool {
+		switch rawValue[i] {
+		case '[':
+			depth++
+		case ']':
+			depth--
+		}
+
+		return true
+	})
+
+	return closed && depth <= 0
+}
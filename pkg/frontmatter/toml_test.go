@@ -0,0 +1,78 @@
+package frontmatter
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestParseTOML(t *testing.T) {
+	tests := []struct {
+		name       string
+		block      string
+		wantParams map[string]any
+		wantErr    error
+	}{
+		{
+			name:  "shouldParseKeyValuePairs",
+			block: "title = \"Hello # world\" # comment\nliteral = 'C:\\path'\nweight = 1_000\ndraft = true\ndate = 2024-10-01T10:30:00Z",
+			wantParams: map[string]any{
+				"title":   "Hello # world",
+				"literal": "C:\\path",
+				"weight":  1000,
+				"draft":   true,
+				"date":    time.Date(2024, 10, 1, 10, 30, 0, 0, time.UTC),
+			},
+			wantErr: nil,
+		},
+		{
+			name:  "shouldParseMultilineArrays",
+			block: "tags = [\n  \"go\", # first\n  \"ssg\",\n]",
+			wantParams: map[string]any{
+				"tags": []any{"go", "ssg"},
+			},
+			wantErr: nil,
+		},
+		{
+			name:  "shouldParseTablesAndDottedKeys",
+			block: "site.name = \"Blog\"\n[menu.main]\nweight = 10\n[\"params\"]\nauthor = \"Nodari\"",
+			wantParams: map[string]any{
+				"site":   map[string]any{"name": "Blog"},
+				"menu":   map[string]any{"main": map[string]any{"weight": 10}},
+				"params": map[string]any{"author": "Nodari"},
+			},
+			wantErr: nil,
+		},
+		{
+			name:       "shouldReturnErrInvalidFrontMatterForMissingEquals",
+			block:      "title \"Hello\"",
+			wantParams: nil,
+			wantErr:    ErrInvalidFrontMatter,
+		},
+		{
+			name:       "shouldReturnErrInvalidFrontMatterForUnclosedArray",
+			block:      "tags = [\n\"go\",",
+			wantParams: nil,
+			wantErr:    ErrInvalidFrontMatter,
+		},
+		{
+			name:       "shouldReturnErrInvalidFrontMatterForRedefinedKey",
+			block:      "menu = \"main\"\n[menu]\nweight = 1",
+			wantParams: nil,
+			wantErr:    ErrInvalidFrontMatter,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotParams, _, gotErr := parseTOML(tt.block, 2)
+			if !reflect.DeepEqual(gotParams, tt.wantParams) || !errors.Is(gotErr, tt.wantErr) {
+				t.Errorf(
+					"parseTOML() = (%v, %v), want (%v, %v)",
+					gotParams, gotErr, tt.wantParams, tt.wantErr,
+				)
+			}
+		})
+	}
+}
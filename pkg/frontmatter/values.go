@@ -0,0 +1,241 @@
+package frontmatter
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var dateLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05Z07:00",
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+	time.DateOnly,
+}
+
+// floatRegexp matches decimal floats of YAML and TOML, e.g. 1.5, -.5 or 1_000.5e-3. Infinity and
+// NaN aren't matched, so that words like inf or Nan stay strings.
+var floatRegexp = regexp.MustCompile(`^[-+]?(\d[\d_]*(\.[\d_]*)?|\.\d[\d_]*)([eE][-+]?\d+)?$`)
+
+func parseDate(value string) (time.Time, bool) {
+	for _, layout := range dateLayouts {
+		if date, err := time.Parse(layout, value); err == nil {
+			return date, true
+		}
+	}
+
+	return time.Time{}, false
+}
+
+// formatDate formats date of front matter without its text back into text, e.g. for a title set
+// by New. Dates without time are formatted without it.
+func formatDate(date time.Time) string {
+	if date.Location() == time.UTC && date.Equal(date.Truncate(24*time.Hour)) {
+		return date.Format(time.DateOnly)
+	}
+
+	return date.Format(time.RFC3339)
+}
+
+// scalarText returns raw front matter value as it is written, e.g. 010 or 2024-05-01 10:00, or
+// the texts of its items if it is an inline list, so that string fields can keep the text of
+// values that parse into other types.
+func scalarText(raw string) any {
+	raw = strings.TrimSpace(raw)
+	if raw == "" || raw[0] != '[' || raw[len(raw)-1] != ']' {
+		return raw
+	}
+
+	items, err := splitOutsideQuotes(raw[1:len(raw)-1], ',')
+	if err != nil {
+		return raw
+	}
+
+	texts := []string{}
+
+	for _, item := range items {
+		if item = strings.TrimSpace(item); item != "" {
+			texts = append(texts, item)
+		}
+	}
+
+	return texts
+}
+
+// parseScalar converts raw front matter value into string, bool, int, float64, time.Time, []any
+// or nil. Unquoted values that don't look like any other type are returned as strings.
+func parseScalar(raw string) (any, error) {
+	raw = strings.TrimSpace(raw)
+
+	switch {
+	case raw == "":
+		return nil, nil
+	case raw[0] == '"':
+		return unquoteDoubleQuoted(raw)
+	case raw[0] == '\'':
+		return unquoteSingleQuoted(raw)
+	case raw[0] == '[':
+		return parseInlineList(raw)
+	}
+
+	switch raw {
+	case "true":
+		return true, nil
+	case "false":
+		return false, nil
+	case "null", "~":
+		return nil, nil
+	}
+
+	if integer, err := strconv.ParseInt(strings.ReplaceAll(raw, "_", ""), 10, 64); err == nil {
+		return int(integer), nil
+	}
+
+	if floatRegexp.MatchString(raw) {
+		if float, err := strconv.ParseFloat(strings.ReplaceAll(raw, "_", ""), 64); err == nil {
+			return float, nil
+		}
+	}
+
+	if date, ok := parseDate(raw); ok {
+		return date, nil
+	}
+
+	return raw, nil
+}
+
+func unquoteDoubleQuoted(raw string) (string, error) {
+	value, err := strconv.Unquote(raw)
+	if err != nil {
+		return "", fmt.Errorf("invalid double-quoted string %s", raw)
+	}
+
+	return value, nil
+}
+
+func unquoteSingleQuoted(raw string) (string, error) {
+	if len(raw) < 2 || raw[len(raw)-1] != '\'' {
+		return "", fmt.Errorf("invalid single-quoted string %s", raw)
+	}
+
+	return strings.ReplaceAll(raw[1:len(raw)-1], "''", "'"), nil
+}
+
+func parseInlineList(raw string) ([]any, error) {
+	if raw[len(raw)-1] != ']' {
+		return nil, fmt.Errorf("list %s is not closed", raw)
+	}
+
+	items, err := splitOutsideQuotes(raw[1:len(raw)-1], ',')
+	if err != nil {
+		return nil, err
+	}
+
+	result := []any{}
+
+	for _, item := range items {
+		if strings.TrimSpace(item) == "" {
+			continue
+		}
+
+		value, err := parseScalar(item)
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, value)
+	}
+
+	return result, nil
+}
+
+// scanOutsideQuotes calls visit with index of every character of text that is not part of a
+// quoted string, until visit returns false. It reports whether all quoted strings are closed.
+func scanOutsideQuotes(text string, visit func(i int) bool) bool {
+	var quote byte
+
+	for i := 0; i < len(text); i++ {
+		char := text[i]
+
+		switch {
+		case quote == '"' && char == '\\':
+			i++
+		case quote == '\'' && char == '\'' && i+1 < len(text) && text[i+1] == '\'':
+			i++
+		case quote != 0:
+			if char == quote {
+				quote = 0
+			}
+		case isQuoteStart(text, i):
+			quote = char
+		case !visit(i):
+			return true
+		}
+	}
+
+	return quote == 0
+}
+
+// isQuoteStart reports whether quote character at index i opens a quoted string, so that
+// apostrophes inside unquoted words (e.g. Tolkien's) aren't mistaken for quotes.
+func isQuoteStart(text string, i int) bool {
+	if text[i] != '"' && text[i] != '\'' {
+		return false
+	}
+
+	return i == 0 || strings.IndexByte(" \t[,=:", text[i-1]) != -1
+}
+
+// splitOutsideQuotes splits text by separator, ignoring separators inside quoted strings and
+// nested lists.
+func splitOutsideQuotes(text string, separator byte) ([]string, error) {
+	parts := []string{}
+	start, depth := 0, 0
+
+	closed := scanOutsideQuotes(text, func(i int) bool {
+		switch text[i] {
+		case '[':
+			depth++
+		case ']':
+			depth--
+		case separator:
+			if depth == 0 {
+				parts = append(parts, text[start:i])
+				start = i + 1
+			}
+		}
+
+		return true
+	})
+
+	if !closed {
+		return nil, fmt.Errorf("unterminated string in %s", text)
+	}
+
+	return append(parts, text[start:]), nil
+}
+
+// stripComment removes trailing # comment from a line, leaving # characters inside quoted
+// strings intact.
+func stripComment(line string) string {
+	commentStart := -1
+
+	scanOutsideQuotes(line, func(i int) bool {
+		if line[i] == '#' && (i == 0 || line[i-1] == ' ' || line[i-1] == '\t') {
+			commentStart = i
+			return false
+		}
+
+		return true
+	})
+
+	if commentStart == -1 {
+		return line
+	}
+
+	return strings.TrimRight(line[:commentStart], " \t")
+}
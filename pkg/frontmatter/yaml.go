@@ -0,0 +1,194 @@
+package frontmatter
+
+import (
+	"fmt"
+	"strings"
+)
+
+type yamlLine struct {
+	number int
+	indent int
+	text   string
+}
+
+type yamlParser struct {
+	lines    []yamlLine
+	position int
+	// texts are raw texts of top level values, see scalarText.
+	texts map[string]any
+}
+
+// parseYAML parses a subset of YAML: nested maps by indentation, block and inline lists and
+// scalars. firstLineNumber is used to report errors with line numbers of the source file. It also
+// returns raw texts of top level values, see scalarText.
+func parseYAML(block string, firstLineNumber int) (map[string]any, map[string]any, error) {
+	parser := &yamlParser{texts: map[string]any{}}
+
+	for i, line := range strings.Split(block, "\n") {
+		line = strings.TrimRight(line, " \t\r")
+		text := strings.TrimLeft(line, " ")
+
+		if text == "" || text[0] == '#' {
+			continue
+		}
+
+		if text[0] == '\t' {
+			return nil, nil, fmt.Errorf(
+				"%w: line %d: tabs are not allowed for indentation",
+				ErrInvalidFrontMatter, firstLineNumber+i,
+			)
+		}
+
+		parser.lines = append(parser.lines, yamlLine{firstLineNumber + i, len(line) - len(text), text})
+	}
+
+	if len(parser.lines) == 0 {
+		return map[string]any{}, parser.texts, nil
+	}
+
+	result, err := parser.parseMap(parser.lines[0].indent)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if parser.position < len(parser.lines) {
+		return nil, nil, parser.errorf("unexpected indentation")
+	}
+
+	return result, parser.texts, nil
+}
+
+func (parser *yamlParser) errorf(format string, args ...any) error {
+	lineNumber := parser.lines[len(parser.lines)-1].number
+	if parser.position < len(parser.lines) {
+		lineNumber = parser.lines[parser.position].number
+	}
+
+	return fmt.Errorf(
+		"%w: line %d: %s", ErrInvalidFrontMatter, lineNumber, fmt.Sprintf(format, args...),
+	)
+}
+
+func (parser *yamlParser) current() (yamlLine, bool) {
+	if parser.position >= len(parser.lines) {
+		return yamlLine{}, false
+	}
+
+	return parser.lines[parser.position], true
+}
+
+func (parser *yamlParser) parseMap(indent int) (map[string]any, error) {
+	result := map[string]any{}
+	isTopLevel := indent == parser.lines[0].indent
+
+	for {
+		line, ok := parser.current()
+		if !ok || line.indent < indent {
+			return result, nil
+		}
+
+		if line.indent > indent {
+			return nil, parser.errorf("unexpected indentation")
+		}
+
+		if isYAMLListItem(line.text) {
+			return nil, parser.errorf("unexpected list item")
+		}
+
+		key, rawValue, err := splitYAMLKeyValue(line.text)
+		if err != nil {
+			return nil, parser.errorf("%v", err)
+		}
+
+		if _, exists := result[key]; exists {
+			return nil, parser.errorf("duplicate key %q", key)
+		}
+
+		parser.position++
+
+		rawValue = stripComment(rawValue)
+		if rawValue != "" {
+			value, err := parseScalar(rawValue)
+			if err != nil {
+				return nil, parser.errorf("%v", err)
+			}
+
+			result[key] = value
+			if isTopLevel {
+				parser.texts[key] = scalarText(rawValue)
+			}
+
+			continue
+		}
+
+		next, ok := parser.current()
+
+		switch {
+		case ok && isYAMLListItem(next.text) && next.indent >= indent:
+			var texts []string
+			result[key], texts, err = parser.parseList(next.indent)
+			if isTopLevel {
+				parser.texts[key] = texts
+			}
+		case ok && next.indent > indent:
+			result[key], err = parser.parseMap(next.indent)
+		default:
+			result[key] = nil
+		}
+
+		if err != nil {
+			return nil, err
+		}
+	}
+}
+
+// parseList returns items of the block list at indent and their raw texts.
+func (parser *yamlParser) parseList(indent int) ([]any, []string, error) {
+	result := []any{}
+	texts := []string{}
+
+	for {
+		line, ok := parser.current()
+		if !ok || line.indent != indent || !isYAMLListItem(line.text) {
+			return result, texts, nil
+		}
+
+		rawValue := stripComment(strings.TrimPrefix(line.text, "-"))
+
+		value, err := parseScalar(rawValue)
+		if err != nil {
+			return nil, nil, parser.errorf("%v", err)
+		}
+
+		result = append(result, value)
+		texts = append(texts, strings.TrimSpace(rawValue))
+		parser.position++
+	}
+}
+
+func isYAMLListItem(text string) bool {
+	return text == "-" || strings.HasPrefix(text, "- ")
+}
+
+func splitYAMLKeyValue(text string) (string, string, error) {
+	separatorIndex := strings.Index(text, ": ")
+	if separatorIndex == -1 && strings.HasSuffix(text, ":") {
+		separatorIndex = len(text) - 1
+	}
+
+	if separatorIndex <= 0 {
+		return "", "", fmt.Errorf("expected \"key: value\", got %q", text)
+	}
+
+	key := strings.TrimSpace(text[:separatorIndex])
+	if key[0] == '"' || key[0] == '\'' {
+		unquotedKey, err := parseScalar(key)
+		if err != nil {
+			return "", "", err
+		}
+
+		key = fmt.Sprint(unquotedKey)
+	}
+
+	return key, strings.TrimSpace(text[separatorIndex+1:]), nil
+}
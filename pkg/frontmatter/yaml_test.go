@@ -0,0 +1,90 @@
+package frontmatter
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestParseYAML(t *testing.T) {
+	tests := []struct {
+		name       string
+		block      string
+		wantParams map[string]any
+		wantErr    error
+	}{
+		{
+			name:       "shouldReturnEmptyMapForEmptyBlock",
+			block:      "\n  \n# just a comment\n",
+			wantParams: map[string]any{},
+			wantErr:    nil,
+		},
+		{
+			name:  "shouldParseScalars",
+			block: "string: Tolkien's world # comment\nquoted: 'It''s # not a comment'\nint: 42\nfloat: 4.2\nbool: false\nnull: ~\nurl: https://example.com",
+			wantParams: map[string]any{
+				"string": "Tolkien's world",
+				"quoted": "It's # not a comment",
+				"int":    42,
+				"float":  4.2,
+				"bool":   false,
+				"null":   nil,
+				"url":    "https://example.com",
+			},
+			wantErr: nil,
+		},
+		{
+			name:  "shouldParseBlockAndInlineLists",
+			block: "tags:\n  - go\n  - \"static, site\"\ncategories:\n- books\nempty: []\ninline: [1, 'a, b', [x]]",
+			wantParams: map[string]any{
+				"tags":       []any{"go", "static, site"},
+				"categories": []any{"books"},
+				"empty":      []any{},
+				"inline":     []any{1, "a, b", []any{"x"}},
+			},
+			wantErr: nil,
+		},
+		{
+			name:  "shouldParseNestedMaps",
+			block: "menu:\n  main:\n    weight: 10\n    name: Home\n  footer:\nafter: value",
+			wantParams: map[string]any{
+				"menu": map[string]any{
+					"main":   map[string]any{"weight": 10, "name": "Home"},
+					"footer": nil,
+				},
+				"after": "value",
+			},
+			wantErr: nil,
+		},
+		{
+			name:       "shouldReturnErrInvalidFrontMatterForUnexpectedIndentation",
+			block:      "title: Hello\n  date: 2024-10-01",
+			wantParams: nil,
+			wantErr:    ErrInvalidFrontMatter,
+		},
+		{
+			name:       "shouldReturnErrInvalidFrontMatterForDuplicateKey",
+			block:      "title: Hello\ntitle: World",
+			wantParams: nil,
+			wantErr:    ErrInvalidFrontMatter,
+		},
+		{
+			name:       "shouldReturnErrInvalidFrontMatterForUnclosedList",
+			block:      "tags: [go, ssg",
+			wantParams: nil,
+			wantErr:    ErrInvalidFrontMatter,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotParams, _, gotErr := parseYAML(tt.block, 2)
+			if !reflect.DeepEqual(gotParams, tt.wantParams) || !errors.Is(gotErr, tt.wantErr) {
+				t.Errorf(
+					"parseYAML() = (%v, %v), want (%v, %v)",
+					gotParams, gotErr, tt.wantParams, tt.wantErr,
+				)
+			}
+		})
+	}
+}
@@ -0,0 +1,206 @@
+package generator
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"slices"
+
+	"static-site-generator/pkg/config"
+	"static-site-generator/pkg/fileutils"
+	"static-site-generator/pkg/manifest"
+	"static-site-generator/pkg/site"
+)
+
+type BuildOptions struct {
+	// Force ignores the manifest of the previous build and regenerates everything from scratch.
+	Force bool
+}
+
+// Build copies static files and generates pages into destination directory. Pages and static
+// files that didn't change since the previous build (according to its manifest) are skipped,
+// outputs of deleted or unpublished sources are removed, unless the build fails.
+func Build(cfg *config.Config, opts BuildOptions) (*Summary, error) {
+	configHash, err := hashConfig(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("build failed: %v", err)
+	}
+
+	previous := loadPreviousManifest(cfg, configHash, opts.Force)
+	if previous == nil {
+		if err := fileutils.CleanDestinationDir(cfg.DestinationDir); err != nil {
+			return nil, fmt.Errorf("build failed: %v", err)
+		}
+
+		previous = manifest.New(cfg.DestinationDir, configHash)
+	}
+
+	current := manifest.New(cfg.DestinationDir, configHash)
+
+	if err := syncStaticFiles(cfg, previous, current); err != nil {
+		return nil, fmt.Errorf("build failed: %v", err)
+	}
+
+	summary, generateErr := GeneratePagesRecursive(cfg, previous, current)
+
+	errs := []error{generateErr}
+
+	if generateErr != nil {
+		keepPreviousOutputs(previous, current)
+	} else {
+		errs = append(errs, removeStaleOutputs(cfg, previous, current))
+	}
+
+	errs = append(errs, current.Save(cfg.ManifestPath))
+
+	if err := errors.Join(errs...); err != nil {
+		return nil, fmt.Errorf("build failed: %w", err)
+	}
+
+	return summary, nil
+}
+
+func loadPreviousManifest(cfg *config.Config, configHash string, force bool) *manifest.Manifest {
+	if force {
+		fmt.Println("Forced build, ignoring manifest of the previous build.")
+		return nil
+	}
+
+	previous, err := manifest.Load(cfg.ManifestPath)
+	if errors.Is(err, manifest.ErrManifestNotFound) {
+		return nil
+	}
+	if err != nil {
+		fmt.Printf("Ignoring manifest of the previous build: %v.\n", err)
+		return nil
+	}
+
+	if filepath.Clean(previous.DestinationDir) != filepath.Clean(cfg.DestinationDir) {
+		fmt.Println("Ignoring manifest of the previous build: destination directory changed.")
+		return nil
+	}
+
+	if previous.ConfigHash != configHash {
+		fmt.Println("Config changed since the previous build, regenerating every page.")
+	}
+
+	return previous
+}
+
+// hashConfig hashes config values that affect generated files.
+func hashConfig(cfg *config.Config) (string, error) {
+	relevantConfig := *cfg
+	relevantConfig.ServerPort = 0
+	relevantConfig.Workers = 0
+
+	data, err := json.Marshal(relevantConfig)
+	if err != nil {
+		return "", fmt.Errorf("couldn't hash config: %v", err)
+	}
+
+	return manifest.HashBytes(data), nil
+}
+
+// hashSite hashes data of published pages (including list pages) that templates can use on other
+// pages (URLs, titles, sections, front matter and summaries), URLs of resources and the site's
+// data files, for sites of all languages. Changing it regenerates every page, while changes of a
+// page's content alone only regenerate that page.
+func hashSite(websites []*site.Site) (string, error) {
+	type pageData struct {
+		URL     string
+		Title   string
+		Section string
+		Params  map[string]any
+		Summary string
+	}
+
+	pages := []pageData{}
+	resourceURLs := []string{}
+	siteData := map[string]any{}
+
+	for _, website := range websites {
+		for _, page := range slices.Concat(website.Pages, website.ListPages) {
+			pages = append(
+				pages, pageData{page.URL, page.Title, page.Section, page.Params, string(page.Summary)},
+			)
+		}
+
+		for _, resource := range website.Resources {
+			resourceURLs = append(resourceURLs, resource.URL)
+		}
+
+		siteData = website.Data
+	}
+
+	data, err := json.Marshal(struct {
+		Pages     []pageData
+		Resources []string
+		Data      map[string]any
+	}{pages, resourceURLs, siteData})
+	if err != nil {
+		return "", fmt.Errorf("couldn't hash site: %v", err)
+	}
+
+	return manifest.HashBytes(data), nil
+}
+
+// keepPreviousOutputs carries outputs of the previous build that the current, failed build
+// didn't generate over to the current manifest, so that a failing page or layout removes nothing
+// from destination directory. Carried pages are marked as failed, so that the next build
+// regenerates them, and the next successful build removes the ones that are stale.
+func keepPreviousOutputs(previous, current *manifest.Manifest) {
+	for destinationPath, entry := range previous.Pages {
+		if _, found := current.Pages[destinationPath]; !found {
+			entry.SourceHash = ""
+			current.Pages[destinationPath] = entry
+		}
+	}
+
+	for relativePath, sourceHash := range previous.StaticFiles {
+		if _, found := current.StaticFiles[relativePath]; !found {
+			current.StaticFiles[relativePath] = sourceHash
+		}
+	}
+}
+
+// removeStaleOutputs removes files generated by the previous build that the current build
+// didn't generate, e.g. pages whose source was deleted or became a draft.
+func removeStaleOutputs(cfg *config.Config, previous, current *manifest.Manifest) error {
+	currentOutputs := map[string]bool{}
+
+	for _, entry := range current.Pages {
+		currentOutputs[filepath.Clean(entry.DestinationPath)] = true
+	}
+
+	for relativePath := range current.StaticFiles {
+		currentOutputs[filepath.Join(cfg.DestinationDir, relativePath)] = true
+	}
+
+	staleOutputs := []string{}
+
+	for _, entry := range previous.Pages {
+		staleOutputs = append(staleOutputs, filepath.Clean(entry.DestinationPath))
+	}
+
+	for relativePath := range previous.StaticFiles {
+		staleOutputs = append(staleOutputs, filepath.Join(cfg.DestinationDir, relativePath))
+	}
+
+	errs := []error{}
+
+	for _, staleOutput := range staleOutputs {
+		if currentOutputs[staleOutput] {
+			continue
+		}
+
+		fmt.Printf("Removing stale output (%s)...\n", staleOutput)
+
+		err := fileutils.RemoveFileAndEmptyParentDirs(staleOutput, cfg.DestinationDir)
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
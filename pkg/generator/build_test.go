@@ -0,0 +1,272 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"static-site-generator/pkg/config"
+	"static-site-generator/pkg/manifest"
+)
+
+const TEST_OUTPUT = "<p>Hi</p>"
+
+// newTestBuild writes TEST_OUTPUT to index.html of a temporary destination directory and returns
+// config of it, the output's manifest entry and the manifest of the build that generated it.
+func newTestBuild(t *testing.T) (*config.Config, manifest.PageEntry, *manifest.Manifest) {
+	t.Helper()
+
+	dir := t.TempDir()
+	cfg := &config.Config{
+		DestinationDir: filepath.Join(dir, "public"),
+		ManifestPath:   filepath.Join(dir, ".build-manifest.json"),
+	}
+
+	entry := manifest.PageEntry{
+		SourcePath:      filepath.Join(dir, "content", "index.md"),
+		SourceHash:      "source-hash",
+		LayoutHash:      "layout-hash",
+		DestinationPath: filepath.Join(cfg.DestinationDir, "index.html"),
+		OutputHash:      manifest.HashBytes([]byte(TEST_OUTPUT)),
+	}
+
+	if err := os.MkdirAll(cfg.DestinationDir, 0o755); err != nil {
+		t.Fatalf("MkdirAll() error = %v", err)
+	}
+
+	if err := os.WriteFile(entry.DestinationPath, []byte(TEST_OUTPUT), 0o644); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+
+	previous := manifest.New(cfg.DestinationDir, "config-hash")
+	previous.SiteHash = "site-hash"
+	previous.Pages[entry.DestinationPath] = entry
+
+	return cfg, entry, previous
+}
+
+func newCurrentManifest(previous *manifest.Manifest) *manifest.Manifest {
+	current := manifest.New(previous.DestinationDir, previous.ConfigHash)
+	current.SiteHash = previous.SiteHash
+
+	return current
+}
+
+func TestIsPageUpToDate(t *testing.T) {
+	tests := []struct {
+		name   string
+		change func(entry *manifest.PageEntry, current *manifest.Manifest)
+		want   bool
+	}{
+		{
+			name:   "shouldBeUpToDateForUnchangedPage",
+			change: func(entry *manifest.PageEntry, current *manifest.Manifest) {},
+			want:   true,
+		},
+		{
+			name: "shouldRegenerateForChangedSource",
+			change: func(entry *manifest.PageEntry, current *manifest.Manifest) {
+				entry.SourceHash = "changed-source-hash"
+			},
+		},
+		{
+			name: "shouldRegenerateForChangedLayoutHash",
+			change: func(entry *manifest.PageEntry, current *manifest.Manifest) {
+				entry.LayoutHash = "changed-layout-hash"
+			},
+		},
+		{
+			name: "shouldRegenerateForChangedSiteHash",
+			change: func(entry *manifest.PageEntry, current *manifest.Manifest) {
+				current.SiteHash = "changed-site-hash"
+			},
+		},
+		{
+			name: "shouldRegenerateForChangedConfigHash",
+			change: func(entry *manifest.PageEntry, current *manifest.Manifest) {
+				current.ConfigHash = "changed-config-hash"
+			},
+		},
+		{
+			name: "shouldRegenerateOutputEditedByHand",
+			change: func(entry *manifest.PageEntry, current *manifest.Manifest) {
+				os.WriteFile(entry.DestinationPath, []byte("<p>Edited</p>"), 0o644)
+			},
+		},
+		{
+			name: "shouldRegenerateDeletedOutput",
+			change: func(entry *manifest.PageEntry, current *manifest.Manifest) {
+				os.Remove(entry.DestinationPath)
+			},
+		},
+		{
+			name: "shouldRegenerateNewPage",
+			change: func(entry *manifest.PageEntry, current *manifest.Manifest) {
+				entry.DestinationPath = filepath.Join(filepath.Dir(entry.DestinationPath), "new.html")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, entry, previous := newTestBuild(t)
+			current := newCurrentManifest(previous)
+
+			tt.change(&entry, current)
+
+			got, err := isPageUpToDate(entry, entry.SourcePath, previous, current)
+			if err != nil || got != tt.want {
+				t.Errorf("isPageUpToDate() = (%v, %v), want (%v, nil)", got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestWriteOutput(t *testing.T) {
+	tests := []struct {
+		name        string
+		data        string
+		onDisk      string
+		wantWritten bool
+	}{
+		{"shouldSkipUnchangedOutput", TEST_OUTPUT, TEST_OUTPUT, false},
+		{"shouldWriteChangedOutput", "<p>Bye</p>", TEST_OUTPUT, true},
+		{"shouldRewriteOutputEditedByHand", TEST_OUTPUT, "<p>Edited</p>", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, entry, previous := newTestBuild(t)
+			current := newCurrentManifest(previous)
+
+			if err := os.WriteFile(entry.DestinationPath, []byte(tt.onDisk), 0o644); err != nil {
+				t.Fatalf("WriteFile() error = %v", err)
+			}
+
+			written, err := writeOutput(entry, []byte(tt.data), previous, current)
+			if err != nil || written != tt.wantWritten {
+				t.Errorf("writeOutput() = (%v, %v), want (%v, nil)", written, err, tt.wantWritten)
+			}
+
+			output, err := os.ReadFile(entry.DestinationPath)
+			if err != nil || string(output) != tt.data {
+				t.Errorf("output = (%s, %v), want (%s, nil)", output, err, tt.data)
+			}
+
+			wantEntry := entry
+			wantEntry.OutputHash = manifest.HashBytes([]byte(tt.data))
+
+			if got := current.Pages[entry.DestinationPath]; got != wantEntry {
+				t.Errorf("current.Pages[%s] = %+v, want %+v", entry.DestinationPath, got, wantEntry)
+			}
+		})
+	}
+}
+
+func TestRemoveStaleOutputs(t *testing.T) {
+	tests := []struct {
+		name       string
+		regenerate bool
+		wantExists bool
+	}{
+		{"shouldRemoveOutputOfSourceDeletedSinceLastBuild", false, false},
+		{"shouldKeepOutputOfCurrentBuild", true, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg, entry, previous := newTestBuild(t)
+			current := newCurrentManifest(previous)
+
+			if tt.regenerate {
+				current.Pages[entry.DestinationPath] = entry
+			}
+
+			if err := removeStaleOutputs(cfg, previous, current); err != nil {
+				t.Fatalf("removeStaleOutputs() error = %v", err)
+			}
+
+			_, err := os.Stat(entry.DestinationPath)
+			if exists := err == nil; exists != tt.wantExists {
+				t.Errorf("output exists = %v, want %v", exists, tt.wantExists)
+			}
+		})
+	}
+}
+
+func TestKeepPreviousOutputs(t *testing.T) {
+	t.Run("shouldKeepOutputOfFailedPage", func(t *testing.T) {
+		_, entry, previous := newTestBuild(t)
+		previous.StaticFiles["index.css"] = "css-hash"
+		current := newCurrentManifest(previous)
+
+		keepPreviousOutputs(previous, current)
+
+		failedEntry := entry
+		failedEntry.SourceHash = ""
+
+		wantPages := map[string]manifest.PageEntry{entry.DestinationPath: failedEntry}
+		if !reflect.DeepEqual(current.Pages, wantPages) {
+			t.Errorf("current.Pages = %+v, want %+v", current.Pages, wantPages)
+		}
+
+		if !reflect.DeepEqual(current.StaticFiles, previous.StaticFiles) {
+			t.Errorf("current.StaticFiles = %+v, want %+v", current.StaticFiles, previous.StaticFiles)
+		}
+
+		next := newCurrentManifest(current)
+
+		upToDate, err := isPageUpToDate(entry, entry.SourcePath, current, next)
+		if err != nil || upToDate {
+			t.Errorf("isPageUpToDate() of next build = (%v, %v), want (false, nil)", upToDate, err)
+		}
+
+		if _, err := os.Stat(entry.DestinationPath); err != nil {
+			t.Errorf("output of failed page (%s) removed: %v", entry.DestinationPath, err)
+		}
+	})
+}
+
+func TestLoadPreviousManifest(t *testing.T) {
+	tests := []struct {
+		name           string
+		save           bool
+		destinationDir string
+		configHash     string
+		force          bool
+		wantLoaded     bool
+	}{
+		{"shouldLoadManifestOfPreviousBuild", true, "", "config-hash", false, true},
+		{"shouldLoadManifestForChangedConfig", true, "", "changed-config-hash", false, true},
+		{"shouldIgnoreMissingManifest", false, "", "config-hash", false, false},
+		{"shouldIgnoreManifestOfForcedBuild", true, "", "config-hash", true, false},
+		{"shouldIgnoreManifestOfOtherDestinationDir", true, "other", "config-hash", false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg, _, previous := newTestBuild(t)
+
+			if tt.save {
+				if err := previous.Save(cfg.ManifestPath); err != nil {
+					t.Fatalf("Save() error = %v", err)
+				}
+			}
+
+			if tt.destinationDir != "" {
+				cfg.DestinationDir = filepath.Join(filepath.Dir(cfg.DestinationDir), tt.destinationDir)
+			}
+
+			got := loadPreviousManifest(cfg, tt.configHash, tt.force)
+
+			if tt.wantLoaded && !reflect.DeepEqual(got, previous) {
+				t.Errorf("loadPreviousManifest() = %+v, want %+v", got, previous)
+			}
+
+			if !tt.wantLoaded && got != nil {
+				t.Errorf("loadPreviousManifest() = %+v, want nil", got)
+			}
+		})
+	}
+}
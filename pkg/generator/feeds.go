@@ -0,0 +1,331 @@
+package generator
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"net/mail"
+	"os"
+	"regexp"
+	"time"
+
+	"static-site-generator/pkg/config"
+	"static-site-generator/pkg/manifest"
+	"static-site-generator/pkg/site"
+)
+
+const ATOM_NAMESPACE = "http://www.w3.org/2005/Atom"
+
+type rssFeed struct {
+	XMLName xml.Name   `xml:"rss"`
+	Version string     `xml:"version,attr"`
+	AtomNS  string     `xml:"xmlns:atom,attr"`
+	Channel rssChannel `xml:"channel"`
+}
+
+type rssChannel struct {
+	Title         string    `xml:"title"`
+	Link          string    `xml:"link"`
+	Description   string    `xml:"description"`
+	Language      string    `xml:"language,omitempty"`
+	Author        string    `xml:"managingEditor,omitempty"`
+	LastBuildDate string    `xml:"lastBuildDate,omitempty"`
+	SelfLink      atomLink  `xml:"atom:link"`
+	Items         []rssItem `xml:"item"`
+}
+
+type rssItem struct {
+	Title       string `xml:"title"`
+	Link        string `xml:"link"`
+	GUID        string `xml:"guid"`
+	PubDate     string `xml:"pubDate,omitempty"`
+	Description string `xml:"description"`
+}
+
+type atomFeed struct {
+	XMLName xml.Name    `xml:"feed"`
+	XMLNS   string      `xml:"xmlns,attr"`
+	Lang    string      `xml:"xml:lang,attr,omitempty"`
+	Title   string      `xml:"title"`
+	ID      string      `xml:"id"`
+	Updated string      `xml:"updated"`
+	Links   []atomLink  `xml:"link"`
+	Author  *atomAuthor `xml:"author"`
+	Entries []atomEntry `xml:"entry"`
+}
+
+type atomLink struct {
+	Href string `xml:"href,attr"`
+	Rel  string `xml:"rel,attr,omitempty"`
+	Type string `xml:"type,attr,omitempty"`
+}
+
+type atomAuthor struct {
+	Name string `xml:"name"`
+}
+
+type atomEntry struct {
+	Title     string    `xml:"title"`
+	ID        string    `xml:"id"`
+	Updated   string    `xml:"updated"`
+	Published string    `xml:"published,omitempty"`
+	Link      atomLink  `xml:"link"`
+	Summary   *atomText `xml:"summary"`
+	Content   *atomText `xml:"content"`
+}
+
+type atomText struct {
+	Type string `xml:"type,attr"`
+	Text string `xml:",chardata"`
+}
+
+// feedOutput is a file of a feed in one of the formats.
+type feedOutput struct {
+	destinationPath string
+	render          func(website *site.Site, feed *site.Feed) ([]byte, error)
+}
+
+// generateFeeds writes RSS, Atom and JSON Feed files of every feed of websites (sites of all
+// languages), unless they didn't change since the previous build. Feeds are recorded in the
+// current manifest under the source path of their page.
+func generateFeeds(websites []*site.Site, previous, current *manifest.Manifest) error {
+	errs := []error{}
+
+	for _, website := range websites {
+		for _, feed := range website.Feeds {
+			outputs := []feedOutput{
+				{feed.RSSDestinationPath, renderRSS},
+				{feed.AtomDestinationPath, renderAtom},
+			}
+
+			if feed.JSONFeedURL != "" {
+				outputs = append(outputs, feedOutput{feed.JSONFeedDestinationPath, renderJSONFeed})
+			}
+
+			for _, output := range outputs {
+				feedXML, err := output.render(website, feed)
+				if err != nil {
+					errs = append(errs, fmt.Errorf(
+						"generating feed (%s) of page (%s) failed: %v",
+						output.destinationPath, feed.Page.SourcePath, err,
+					))
+					continue
+				}
+
+				entry := manifest.PageEntry{
+					SourcePath:      feed.Page.SourcePath,
+					SourceHash:      feed.Page.SourceHash,
+					DestinationPath: output.destinationPath,
+				}
+
+				written, err := writeOutput(entry, feedXML, previous, current)
+				if err != nil {
+					errs = append(errs, fmt.Errorf(
+						"generating feed (%s) of page (%s) failed: %v",
+						output.destinationPath, feed.Page.SourcePath, err,
+					))
+					continue
+				}
+
+				if written {
+					fmt.Printf("Generated feed (%s).\n", output.destinationPath)
+				}
+			}
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// renderRSS renders feed of website as RSS 2.0.
+func renderRSS(website *site.Site, feed *site.Feed) ([]byte, error) {
+	channel := rssChannel{
+		Title:       feed.Title,
+		Link:        website.BaseURL + feed.Page.URL,
+		Description: feedDescription(feed),
+		Language:    website.LanguageCode,
+		Author:      rssAuthor(website.Author),
+		SelfLink: atomLink{
+			Href: website.BaseURL + feed.RSSURL, Rel: "self", Type: "application/rss+xml",
+		},
+		Items: []rssItem{},
+	}
+
+	channel.LastBuildDate = feedUpdated(feed).Format(time.RFC1123Z)
+
+	for _, page := range feed.Pages {
+		item := rssItem{
+			Title:       page.Title,
+			Link:        website.BaseURL + page.URL,
+			GUID:        website.BaseURL + page.URL,
+			Description: feedContent(website.Config, page),
+		}
+
+		if !page.Date.IsZero() {
+			item.PubDate = page.Date.Format(time.RFC1123Z)
+		}
+
+		channel.Items = append(channel.Items, item)
+	}
+
+	return marshalFeed(rssFeed{Version: "2.0", AtomNS: ATOM_NAMESPACE, Channel: channel})
+}
+
+// renderAtom renders feed of website as Atom. Atom requires dates of entries, pages without date
+// get the modification time of their source file.
+func renderAtom(website *site.Site, feed *site.Feed) ([]byte, error) {
+	updated := feedUpdated(feed).Format(time.RFC3339)
+
+	atom := atomFeed{
+		XMLNS:   ATOM_NAMESPACE,
+		Lang:    website.LanguageCode,
+		Title:   feed.Title,
+		ID:      website.BaseURL + feed.Page.URL,
+		Updated: updated,
+		Links: []atomLink{
+			{Href: website.BaseURL + feed.Page.URL, Rel: "alternate", Type: "text/html"},
+			{Href: website.BaseURL + feed.AtomURL, Rel: "self", Type: "application/atom+xml"},
+		},
+		Entries: []atomEntry{},
+	}
+
+	if website.Author != "" {
+		atom.Author = &atomAuthor{website.Author}
+	}
+
+	for _, page := range feed.Pages {
+		entry := atomEntry{
+			Title:   page.Title,
+			ID:      website.BaseURL + page.URL,
+			Updated: updated,
+			Link:    atomLink{Href: website.BaseURL + page.URL, Rel: "alternate"},
+		}
+
+		if modified := pageUpdated(page); !modified.IsZero() {
+			entry.Updated = modified.Format(time.RFC3339)
+		}
+
+		if !page.Date.IsZero() {
+			entry.Published = entry.Updated
+		}
+
+		text := &atomText{Type: "html", Text: feedContent(website.Config, page)}
+		if website.FeedFullContent {
+			entry.Content = text
+		} else {
+			entry.Summary = text
+		}
+
+		atom.Entries = append(atom.Entries, entry)
+	}
+
+	return marshalFeed(atom)
+}
+
+func marshalFeed(feed any) ([]byte, error) {
+	feedXML, err := xml.MarshalIndent(feed, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	return append([]byte(xml.Header), append(feedXML, '\n')...), nil
+}
+
+// feedDescription returns description front matter of feed's page, or a description made of the
+// feed's title.
+func feedDescription(feed *site.Feed) string {
+	if feed.Page.Description != "" {
+		return feed.Page.Description
+	}
+
+	return "Recent pages of " + feed.Title
+}
+
+// feedUpdated returns the date of the newest page of feed, or the newest modification time of
+// source files of the feed's pages (and of its own page) if no page has a date. It returns the
+// current time if there are neither, since feeds require a date.
+func feedUpdated(feed *site.Feed) time.Time {
+	updated := time.Time{}
+
+	for _, page := range feed.Pages {
+		if page.Date.After(updated) {
+			updated = page.Date
+		}
+	}
+
+	if !updated.IsZero() {
+		return updated
+	}
+
+	for _, page := range append([]*site.Page{feed.Page}, feed.Pages...) {
+		if modTime := pageUpdated(page); modTime.After(updated) {
+			updated = modTime
+		}
+	}
+
+	if updated.IsZero() {
+		return time.Now().UTC()
+	}
+
+	return updated
+}
+
+// pageUpdated returns the date of page, or the modification time of its source file if it has no
+// date, zero if it has neither.
+func pageUpdated(page *site.Page) time.Time {
+	if !page.Date.IsZero() || page.SourcePath == "" {
+		return page.Date
+	}
+
+	info, err := os.Stat(page.SourcePath)
+	if err != nil {
+		return time.Time{}
+	}
+
+	return info.ModTime().UTC().Truncate(time.Second)
+}
+
+// rssAuthor returns author as RSS expects it, an email address optionally followed by the name in
+// parentheses, e.g. "me@example.com (Me)". It is empty if author has no email address, e.g. if it
+// is only a name.
+func rssAuthor(author string) string {
+	address, err := mail.ParseAddress(author)
+	if err != nil {
+		return ""
+	}
+
+	if address.Name == "" {
+		return address.Address
+	}
+
+	return fmt.Sprintf("%s (%s)", address.Address, address.Name)
+}
+
+// feedContent returns HTML of page's summary (or whole content, if cfg.FeedFullContent is set),
+// with URLs made absolute.
+func feedContent(cfg *config.Config, page *site.Page) string {
+	content := string(page.Summary)
+	if cfg.FeedFullContent {
+		content = string(page.Content)
+	}
+
+	return absoluteURLs(cfg.BaseURL, page.URL, content)
+}
+
+var urlAttributeRegexp = regexp.MustCompile(`\b(href|src)="([^"]*)"`)
+
+// absoluteURLs rewrites relative URLs of href and src attributes of content of the page at pageURL
+// into absolute URLs of the site at baseURL (see site.AbsoluteURL), so that they keep working
+// outside of the site, e.g. in feed readers.
+func absoluteURLs(baseURL, pageURL, content string) string {
+	return urlAttributeRegexp.ReplaceAllStringFunc(content, func(attribute string) string {
+		match := urlAttributeRegexp.FindStringSubmatch(attribute)
+
+		absoluteURL, err := site.AbsoluteURL(baseURL, pageURL, match[2])
+		if err != nil {
+			return attribute
+		}
+
+		return fmt.Sprintf(`%s="%s"`, match[1], absoluteURL)
+	})
+}
@@ -0,0 +1,183 @@
+package generator
+
+import (
+	"encoding/xml"
+	"html/template"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"static-site-generator/pkg/config"
+	"static-site-generator/pkg/frontmatter"
+	"static-site-generator/pkg/site"
+)
+
+func TestAbsoluteURLs(t *testing.T) {
+	tests := []struct {
+		name    string
+		baseURL string
+		content string
+		want    string
+	}{
+		{
+			name:    "shouldPrefixRootRelativeURLs",
+			baseURL: "https://example.com",
+			content: `<a href="/majesty/">a</a><img src="/blog/map.png"></img>`,
+			want: `<a href="https://example.com/majesty/">a</a>` +
+				`<img src="https://example.com/blog/map.png"></img>`,
+		},
+		{
+			name:    "shouldResolveURLsRelativeToPage",
+			baseURL: "https://example.com/docs",
+			content: `<img src="cover.png"></img><a href="#notes">notes</a>`,
+			want: `<img src="https://example.com/docs/blog/post/cover.png"></img>` +
+				`<a href="https://example.com/docs/blog/post/#notes">notes</a>`,
+		},
+		{
+			name:    "shouldKeepPathOfBaseURL",
+			baseURL: "https://example.com/sub",
+			content: `<img src="/blog/bundle/cover.png"></img><a href="notes/">notes</a>`,
+			want: `<img src="https://example.com/sub/blog/bundle/cover.png"></img>` +
+				`<a href="https://example.com/sub/blog/post/notes/">notes</a>`,
+		},
+		{
+			name:    "shouldKeepAbsoluteURLs",
+			baseURL: "https://example.com",
+			content: `<a href="https://boot.dev">a</a><a href="//cdn.example.com/a.js">b</a>` +
+				`<a href="mailto:me@example.com">c</a>`,
+			want: `<a href="https://boot.dev">a</a><a href="//cdn.example.com/a.js">b</a>` +
+				`<a href="mailto:me@example.com">c</a>`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := absoluteURLs(tt.baseURL, "/blog/post/", tt.content); got != tt.want {
+				t.Errorf("absoluteURLs() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRenderFeeds(t *testing.T) {
+	date := time.Date(2024, 10, 1, 12, 0, 0, 0, time.UTC)
+
+	newPage := func(title, url string, date time.Time) *site.Page {
+		return &site.Page{
+			FrontMatter: &frontmatter.FrontMatter{Title: title, Date: date},
+			Title:       title,
+			URL:         url,
+			Summary:     template.HTML(`<p>See <a href="/majesty/">majesty</a>.</p>`),
+		}
+	}
+
+	website := &site.Site{Config: &config.Config{
+		BaseURL: "https://example.com", LanguageCode: "en", Author: "Me",
+	}}
+	feed := &site.Feed{
+		Title: "Blog",
+		Page:  newPage("Blog", "/blog/", time.Time{}),
+		Pages: []*site.Page{
+			newPage("Post", "/blog/post/", date), newPage("Undated", "/blog/undated/", time.Time{}),
+		},
+		RSSURL:  "/blog/index.xml",
+		AtomURL: "/blog/atom.xml",
+	}
+
+	rssXML, err := renderRSS(website, feed)
+	if err != nil {
+		t.Fatalf("renderRSS() error = %v", err)
+	}
+
+	rss := rssFeed{}
+	if err := xml.Unmarshal(rssXML, &rss); err != nil {
+		t.Fatalf("renderRSS() = invalid XML %s: %v", rssXML, err)
+	}
+
+	channel := rss.Channel
+	if channel.Title != "Blog" || channel.Author != "" || len(channel.Items) != 2 ||
+		channel.Items[0].PubDate != "Tue, 01 Oct 2024 12:00:00 +0000" ||
+		channel.Items[1].PubDate != "" ||
+		channel.Items[0].Description != `<p>See <a href="https://example.com/majesty/">majesty</a>.</p>` {
+		t.Errorf("renderRSS() = %s", rssXML)
+	}
+
+	atomXML, err := renderAtom(website, feed)
+	if err != nil {
+		t.Fatalf("renderAtom() error = %v", err)
+	}
+
+	atom := atomFeed{}
+	if err := xml.Unmarshal(atomXML, &atom); err != nil {
+		t.Fatalf("renderAtom() = invalid XML %s: %v", atomXML, err)
+	}
+
+	if atom.Updated != "2024-10-01T12:00:00Z" || len(atom.Entries) != 2 ||
+		atom.Entries[1].Updated != atom.Updated || atom.Entries[0].Summary == nil ||
+		atom.Author == nil || atom.Author.Name != "Me" {
+		t.Errorf("renderAtom() = %s", atomXML)
+	}
+}
+
+func TestRSSAuthor(t *testing.T) {
+	tests := []struct {
+		name   string
+		author string
+		want   string
+	}{
+		{"shouldOmitAuthorWithoutEmailAddress", "Me", ""},
+		{"shouldKeepEmailAddress", "me@example.com", "me@example.com"},
+		{"shouldPutNameAfterEmailAddress", "Me <me@example.com>", "me@example.com (Me)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := rssAuthor(tt.author); got != tt.want {
+				t.Errorf("rssAuthor(%q) = %q, want %q", tt.author, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFeedUpdated(t *testing.T) {
+	date := time.Date(2024, 10, 1, 12, 0, 0, 0, time.UTC)
+	modTime := time.Date(2024, 5, 3, 8, 0, 0, 0, time.UTC)
+
+	sourcePath := filepath.Join(t.TempDir(), "undated.md")
+	if err := os.WriteFile(sourcePath, []byte("Undated."), 0o644); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+
+	if err := os.Chtimes(sourcePath, modTime, modTime); err != nil {
+		t.Fatalf("Chtimes() error = %v", err)
+	}
+
+	dated := &site.Page{FrontMatter: &frontmatter.FrontMatter{Date: date}}
+	undated := &site.Page{FrontMatter: &frontmatter.FrontMatter{}, SourcePath: sourcePath}
+	listPage := &site.Page{FrontMatter: &frontmatter.FrontMatter{}}
+
+	tests := []struct {
+		name  string
+		pages []*site.Page
+		want  time.Time
+	}{
+		{"shouldUseDateOfNewestPage", []*site.Page{undated, dated}, date},
+		{"shouldUseModificationTimeOfUndatedPages", []*site.Page{undated}, modTime},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			feed := &site.Feed{Page: listPage, Pages: tt.pages}
+			if got := feedUpdated(feed); !got.Equal(tt.want) {
+				t.Errorf("feedUpdated() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	t.Run("shouldNeverBeZero", func(t *testing.T) {
+		if got := feedUpdated(&site.Feed{Page: listPage}); got.IsZero() {
+			t.Errorf("feedUpdated() = %v, want a date", got)
+		}
+	})
+}
@@ -1,132 +1,507 @@
 package generator
 
 import (
+	"errors"
 	"fmt"
+	"html/template"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
+	"time"
 
-	"static-site-generator/pkg/adapters"
-	"static-site-generator/pkg/markdown"
+	"static-site-generator/pkg/config"
+	"static-site-generator/pkg/data"
+	"static-site-generator/pkg/manifest"
+	"static-site-generator/pkg/site"
+	"static-site-generator/pkg/templates"
 )
 
-func GeneratePagesRecursive(contentDir, templatePath, destinationDir string) error {
+// PageContext is the data templates are rendered with. Title and Content are kept at the top
+// level for templates that use {{ .Title }} and {{ .Content }} (or legacy placeholders).
+type PageContext struct {
+	Title     string
+	Content   template.HTML
+	Page      *site.Page
+	Section   *site.Section
+	Paginator *site.Paginator
+	Site      *site.Site
+}
+
+type Summary struct {
+	GeneratedPages []string
+	UpToDatePages  []string
+	SkippedPages   []SkippedPage
+}
+
+type pageResult struct {
+	sourcePath      string
+	destinationPath string
+	page            *site.Page
+	website         *site.Site
+	skipReason      string
+	upToDate        bool
+	entry           manifest.PageEntry
+	err             error
+}
+
+// GeneratePagesRecursive generates pages from every Markdown file of content directory, skipping
+// the ones that are up to date according to previous, and records their outputs in current.
+// Errors of all failing pages are reported together, in the order of their source files.
+func GeneratePagesRecursive(cfg *config.Config, previous, current *manifest.Manifest) (*Summary, error) {
 	fmt.Println("Generating pages...")
 
-	handleWalkDirEntry := func(path string, entry os.DirEntry, err error) error {
+	if cfg.BaseURL == "" {
+		fmt.Println("Skipping feeds and sitemap, they need absolute URLs and baseURL isn't set.")
+	}
+
+	renderer, err := templates.LoadRenderer(cfg.LayoutsDir, cfg.TemplatePath, cfg.BaseURL)
+	if err != nil {
+		return nil, fmt.Errorf("generating pages failed: %v", err)
+	}
+
+	sourcePaths, resourcePaths, err := findContentFiles(contentDirs(cfg)...)
+	if err != nil {
+		return nil, fmt.Errorf("generating pages failed: %v", err)
+	}
+
+	languageCfgs := map[string]*config.Config{}
+	for _, code := range cfg.LanguageCodes() {
+		languageCfgs[code] = cfg.ForLanguage(code)
+	}
+
+	results := make([]pageResult, len(sourcePaths))
+	now := time.Now()
+
+	// The first pass loads every page, so that sites are built before any page is rendered.
+	runWorkerPool(cfg.Workers, len(sourcePaths), func(i int) {
+		languageCfg := languageCfgs[site.LanguageOf(cfg, sourcePaths[i])]
+		results[i] = loadPageFromSource(sourcePaths[i], languageCfg, now)
+	})
+
+	siteData, err := data.Load(cfg.DataDir)
+	if err != nil {
+		return nil, fmt.Errorf("generating pages failed: %v", err)
+	}
+
+	websites := []*site.Site{}
+	isLoaded := map[*site.Page]bool{}
+
+	for _, code := range cfg.LanguageCodes() {
+		languageCfg := languageCfgs[code]
+		languageResults := []pageResult{}
+		publishedPages := []*site.Page{}
+		unpublishedPages := []*site.Page{}
+
+		for i := range results {
+			if site.LanguageOf(cfg, results[i].sourcePath) != code {
+				continue
+			}
+
+			languageResults = append(languageResults, results[i])
+
+			if results[i].err != nil {
+				continue
+			}
+
+			if results[i].skipReason != "" {
+				unpublishedPages = append(unpublishedPages, results[i].page)
+				continue
+			}
+
+			publishedPages = append(publishedPages, results[i].page)
+			isLoaded[results[i].page] = true
+		}
+
+		languageResourcePaths := publishedResources(
+			languageCfg, languageResources(cfg, code, resourcePaths, sourcePaths), languageResults,
+		)
+
+		website, err := site.New(
+			languageCfg, publishedPages, unpublishedPages, languageResourcePaths,
+		)
 		if err != nil {
-			return err
+			return nil, fmt.Errorf("generating pages failed: %v", err)
 		}
 
-		if entry.IsDir() {
-			return nil
+		website.Data = siteData
+		websites = append(websites, website)
+	}
+
+	if err := site.LinkTranslations(websites); err != nil {
+		return nil, fmt.Errorf("generating pages failed: %v", err)
+	}
+
+	if err := site.CheckAliases(websites, staticDestinationPaths(cfg, current)); err != nil {
+		return nil, fmt.Errorf("generating pages failed: %v", err)
+	}
+
+	current.SiteHash, err = hashSite(websites)
+	if err != nil {
+		return nil, fmt.Errorf("generating pages failed: %v", err)
+	}
+
+	pageWebsites := map[*site.Page]*site.Site{}
+
+	for _, website := range websites {
+		for _, page := range slices.Concat(website.Pages, website.ListPages) {
+			pageWebsites[page] = website
+
+			// Generated list pages of sections without _index.md have no result of the first pass.
+			if !isLoaded[page] {
+				results = append(results, pageResult{
+					sourcePath:      page.SourcePath,
+					destinationPath: page.DestinationPath,
+					page:            page,
+				})
+			}
 		}
+	}
 
-		if !strings.HasSuffix(entry.Name(), ".md") {
-			return nil
+	pageResults := []*pageResult{}
+
+	for i := range results {
+		if results[i].err == nil && results[i].skipReason == "" {
+			results[i].website = pageWebsites[results[i].page]
+			pageResults = append(pageResults, &results[i])
 		}
+	}
+
+	// The second pass renders published pages with the whole site available to their templates.
+	runWorkerPool(cfg.Workers, len(pageResults), func(i int) {
+		generatePublishedPage(pageResults[i], renderer, previous, current)
+	})
+
+	summary := &Summary{
+		GeneratedPages: []string{},
+		UpToDatePages:  []string{},
+		SkippedPages:   []SkippedPage{},
+	}
+	errs := []error{}
+
+	for _, result := range results {
+		switch {
+		case result.err != nil:
+			errs = append(errs, result.err)
+		case result.skipReason != "":
+			fmt.Printf("Skipped page (%s): %s.\n", result.sourcePath, result.skipReason)
+			summary.SkippedPages = append(
+				summary.SkippedPages, SkippedPage{result.sourcePath, result.skipReason},
+			)
+		case result.upToDate:
+			current.Pages[result.destinationPath] = result.entry
+			summary.UpToDatePages = append(summary.UpToDatePages, result.sourcePath)
+		default:
+			fmt.Printf(
+				"Generated page from source (%s) to destination (%s).\n",
+				result.sourcePath, result.destinationPath,
+			)
+			current.Pages[result.destinationPath] = result.entry
+			summary.GeneratedPages = append(summary.GeneratedPages, result.sourcePath)
+		}
+	}
+
+	outputsErr := errors.Join(
+		generateRedirects(cfg, websites, previous, current),
+		generateFeeds(websites, previous, current),
+		generateSitemap(cfg, websites, previous, current),
+	)
+	for _, website := range websites {
+		outputsErr = errors.Join(outputsErr, copyResources(website, previous, current))
+	}
 
-		relativePath, err := filepath.Rel(contentDir, path)
+	if len(errs) != 0 {
+		return nil, errors.Join(fmt.Errorf(
+			"generating pages failed, %d of %d page(s) have errors:\n%w",
+			len(errs), len(results), errors.Join(errs...),
+		), outputsErr)
+	}
+
+	if outputsErr != nil {
+		return nil, fmt.Errorf("generating pages failed: %w", outputsErr)
+	}
+
+	fmt.Println("Generated pages successfully!")
+
+	return summary, nil
+}
+
+// contentDirs returns content directory and content directories of languages.
+func contentDirs(cfg *config.Config) []string {
+	dirs := []string{cfg.ContentDir}
+
+	for _, code := range cfg.LanguageCodes() {
+		if contentDir := cfg.Languages[code].ContentDir; contentDir != "" {
+			dirs = append(dirs, contentDir)
+		}
+	}
+
+	return dirs
+}
+
+// findContentFiles returns paths of Markdown files of content directories and paths of their
+// other files (resources of pages). Hidden files, e.g. .DS_Store, are ignored.
+func findContentFiles(contentDirs ...string) ([]string, []string, error) {
+	sourcePaths, resourcePaths := []string{}, []string{}
+
+	handleWalkDirEntry := func(path string, entry os.DirEntry, err error) error {
 		if err != nil {
 			return err
 		}
 
-		destinationPath := filepath.Join(destinationDir, relativePath)
-		destinationPath = strings.TrimSuffix(destinationPath, ".md")
-		destinationPath += ".html"
+		switch {
+		case entry.IsDir() || strings.HasPrefix(entry.Name(), "."):
+		case strings.HasSuffix(entry.Name(), ".md"):
+			sourcePaths = append(sourcePaths, path)
+		default:
+			resourcePaths = append(resourcePaths, path)
+		}
+
+		return nil
+	}
+
+	for _, contentDir := range contentDirs {
+		if err := filepath.WalkDir(contentDir, handleWalkDirEntry); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	return sourcePaths, resourcePaths, nil
+}
+
+// staticDestinationPaths returns destination paths of static files copied by syncStaticFiles.
+func staticDestinationPaths(cfg *config.Config, current *manifest.Manifest) []string {
+	paths := []string{}
+
+	for relativePath := range current.StaticFiles {
+		paths = append(paths, filepath.Join(cfg.DestinationDir, relativePath))
+	}
+
+	return paths
+}
+
+// languageResources returns resourcePaths of the site of language with code: files of the
+// language's content directory and files of content directory, which all languages share. Files
+// of a page bundle of content directory (the nearest directory below its root with index.md in
+// any language) are only published for the languages of the bundle's index.md files out of
+// sourcePaths, so that e.g. content/trip/cover.png of content/trip/index.md isn't copied to
+// /ka/trip/cover.png.
+func languageResources(
+	cfg *config.Config, code string, resourcePaths, sourcePaths []string,
+) []string {
+	bundleLanguages := map[string]map[string]bool{}
+
+	for _, sourcePath := range sourcePaths {
+		if site.SourceFileName(cfg, sourcePath) != site.INDEX_FILE {
+			continue
+		}
+
+		dir := filepath.Dir(sourcePath)
+		if bundleLanguages[dir] == nil {
+			bundleLanguages[dir] = map[string]bool{}
+		}
+
+		bundleLanguages[dir][site.LanguageOf(cfg, sourcePath)] = true
+	}
+
+	return slices.DeleteFunc(slices.Clone(resourcePaths), func(resourcePath string) bool {
+		if !config.IsInsideDir(resourcePath, cfg.ContentDir) {
+			return site.LanguageOf(cfg, resourcePath) != code
+		}
+
+		contentDir := filepath.Clean(cfg.ContentDir)
+
+		for dir := filepath.Dir(resourcePath); dir != contentDir; dir = filepath.Dir(dir) {
+			if dir == filepath.Dir(dir) {
+				break
+			}
+
+			if languages, found := bundleLanguages[dir]; found {
+				return !languages[code]
+			}
+		}
 
-		return GeneratePage(path, templatePath, destinationPath)
+		return false
+	})
+}
+
+// publishedResources returns resourcePaths except for files of page bundles (directories with
+// index.md) whose index.md of results failed or isn't published, so that e.g. images of a draft
+// aren't published before it.
+func publishedResources(cfg *config.Config, resourcePaths []string, results []pageResult) []string {
+	unpublishedDirs := []string{}
+
+	for _, result := range results {
+		isUnpublished := result.err != nil || result.skipReason != ""
+		if isUnpublished && site.SourceFileName(cfg, result.sourcePath) == site.INDEX_FILE {
+			unpublishedDirs = append(unpublishedDirs, filepath.Dir(result.sourcePath))
+		}
 	}
 
-	err := filepath.WalkDir(contentDir, handleWalkDirEntry)
+	return slices.DeleteFunc(slices.Clone(resourcePaths), func(resourcePath string) bool {
+		return slices.ContainsFunc(unpublishedDirs, func(dir string) bool {
+			return strings.HasPrefix(resourcePath, dir+string(filepath.Separator))
+		})
+	})
+}
+
+func loadPageFromSource(sourcePath string, cfg *config.Config, now time.Time) pageResult {
+	result := pageResult{sourcePath: sourcePath}
+
+	page, err := site.LoadPage(sourcePath, cfg)
 	if err != nil {
-		return fmt.Errorf("generating pages failed: %v", err)
+		result.err = err
+		return result
 	}
 
-	fmt.Println("Generated pages successfully!")
+	result.page = page
+	result.destinationPath = page.DestinationPath
+	result.skipReason = skipReason(page.FrontMatter, cfg, now)
 
-	return nil
+	return result
 }
 
-func GeneratePage(sourcePath, templatePath, destinationPath string) error {
-	fmt.Printf(
-		"Generating page from source (%s) to destination (%s) using template (%s)...\n",
-		sourcePath, destinationPath, templatePath,
-	)
+// generatePublishedPage renders page of result loaded by the first pass, unless it is up to
+// date, and records the outcome in result.
+func generatePublishedPage(
+	result *pageResult,
+	renderer *templates.Renderer,
+	previous, current *manifest.Manifest,
+) {
+	page := result.page
 
-	sourceBytes, err := os.ReadFile(sourcePath)
+	pageTemplate, err := lookupLayout(page, renderer)
 	if err != nil {
-		return fmt.Errorf(
-			"generating page failed, couldn't read source file (%s): %v", sourcePath, err,
-		)
+		result.err = err
+		return
 	}
 
-	sourceMarkdown := string(sourceBytes)
+	result.entry = manifest.PageEntry{
+		SourcePath:      page.SourcePath,
+		SourceHash:      page.SourceHash,
+		LayoutHash:      pageTemplate.Hash,
+		DestinationPath: page.DestinationPath,
+	}
 
-	title, err := markdown.ExtractMarkdownTitle(sourceMarkdown)
+	result.upToDate, err = isPageUpToDate(result.entry, page.SourcePath, previous, current)
 	if err != nil {
-		return fmt.Errorf(
-			"generating page failed, couldn't extract title from markdown: %v", err,
-		)
+		result.err = err
+		return
 	}
 
-	contentHTMLNode, err := adapters.MarkdownToHTMLNode(sourceMarkdown)
+	if result.upToDate {
+		result.entry.OutputHash = previous.Pages[page.DestinationPath].OutputHash
+		return
+	}
+
+	pageHTML, err := GeneratePage(page, result.website, pageTemplate)
 	if err != nil {
-		return fmt.Errorf(
-			"generating page failed, couldn't transform markdown to HTML node: %v", err,
-		)
+		result.err = err
+		return
+	}
+
+	result.entry.OutputHash = manifest.HashBytes(pageHTML)
+}
+
+// isPageUpToDate reports whether page described by entry was generated by the previous build
+// from the same source, layout, config and site, and its output wasn't modified since.
+func isPageUpToDate(
+	entry manifest.PageEntry, sourcePath string, previous, current *manifest.Manifest,
+) (bool, error) {
+	if previous.ConfigHash != current.ConfigHash || previous.SiteHash != current.SiteHash {
+		return false, nil
+	}
+
+	previousEntry, found := previous.Pages[entry.DestinationPath]
+	if !found || previousEntry.OutputHash == "" {
+		return false, nil
+	}
+
+	if previousEntry.SourceHash != entry.SourceHash ||
+		previousEntry.LayoutHash != entry.LayoutHash ||
+		previousEntry.DestinationPath != entry.DestinationPath {
+		return false, nil
 	}
 
-	content, err := contentHTMLNode.ToHTML()
+	outputHash, err := manifest.HashFile(entry.DestinationPath)
 	if err != nil {
-		return fmt.Errorf(
-			"generating page failed, couldn't transform HTML Node to HTML: %v", err,
+		return false, fmt.Errorf(
+			"generating page (%s) failed, couldn't hash destination file (%s): %v",
+			sourcePath, entry.DestinationPath, err,
 		)
 	}
 
-	templateBytes, err := os.ReadFile(templatePath)
+	return outputHash == previousEntry.OutputHash, nil
+}
+
+func (summary *Summary) Print() {
+	fmt.Printf(
+		"Generated %d page(s), %d page(s) up to date, skipped %d page(s).\n",
+		len(summary.GeneratedPages), len(summary.UpToDatePages), len(summary.SkippedPages),
+	)
+
+	for _, skippedPage := range summary.SkippedPages {
+		fmt.Printf("  skipped %s: %s\n", skippedPage.SourcePath, skippedPage.Reason)
+	}
+}
+
+// kindLayoutNames are keyed by page kinds, list pages of taxonomies and terms fall back to the list
+// layout.
+var kindLayoutNames = map[string][]string{
+	site.PAGE_KIND_PAGE:     {templates.SINGLE_LAYOUT},
+	site.PAGE_KIND_SECTION:  {templates.LIST_LAYOUT},
+	site.PAGE_KIND_TAXONOMY: {templates.TAXONOMY_LAYOUT, templates.LIST_LAYOUT},
+	site.PAGE_KIND_TERM:     {templates.TERM_LAYOUT, templates.LIST_LAYOUT},
+}
+
+func lookupLayout(page *site.Page, renderer *templates.Renderer) (*templates.Template, error) {
+	layoutNames := kindLayoutNames[page.Kind]
+	if page.Layout != "" {
+		layoutNames = []string{page.Layout}
+	}
+
+	pageTemplate, err := renderer.Lookup(page.Section, layoutNames...)
 	if err != nil {
-		return fmt.Errorf(
-			"generating page failed, couldn't read template file (%s): %v", templatePath, err,
-		)
+		return nil, fmt.Errorf("generating page (%s) failed: %v", page.SourcePath, err)
 	}
 
-	template := string(templateBytes)
-	template = strings.Replace(template, "{{ Title }}", title, 1)
-	template = strings.Replace(template, "{{ Content }}", content, 1)
+	return pageTemplate, nil
+}
 
-	destinationDir := filepath.Dir(destinationPath)
+// GeneratePage renders page with pageTemplate and writes it to page's destination path. It
+// returns the written HTML. It is safe to call concurrently for different pages.
+func GeneratePage(page *site.Page, website *site.Site, pageTemplate *templates.Template) ([]byte, error) {
+	pageContext := &PageContext{
+		Title:     page.Title,
+		Content:   page.Content,
+		Page:      page,
+		Section:   page.CurrentSection,
+		Paginator: page.Paginator,
+		Site:      website,
+	}
 
-	err = os.MkdirAll(destinationDir, 0o755)
+	pageHTML, err := pageTemplate.Render(pageContext)
 	if err != nil {
-		return fmt.Errorf(
-			"generating page failed, couldn't create destination file's directory (%s): %v",
-			destinationDir, err,
-		)
+		return nil, fmt.Errorf("generating page (%s) failed: %v", page.SourcePath, err)
 	}
 
-	file, err := os.Create(destinationPath)
+	destinationDir := filepath.Dir(page.DestinationPath)
+
+	err = os.MkdirAll(destinationDir, 0o755)
 	if err != nil {
-		return fmt.Errorf(
-			"generating page failed, couldn't open or create destination file (%s): %v",
-			destinationPath, err,
+		return nil, fmt.Errorf(
+			"generating page failed, couldn't create destination file's directory (%s): %v",
+			destinationDir, err,
 		)
 	}
 
-	defer file.Close()
-
-	_, err = file.WriteString(template)
+	err = os.WriteFile(page.DestinationPath, pageHTML, 0o644)
 	if err != nil {
-		return fmt.Errorf(
+		return nil, fmt.Errorf(
 			"generating page failed, couldn't write to destination file (%s): %v",
-			destinationPath, err,
+			page.DestinationPath, err,
 		)
 	}
 
-	fmt.Printf(
-		"Generated page from source (%s) to destination (%s) using template (%s) Successfully!\n",
-		sourcePath, destinationPath, templatePath,
-	)
-
-	return nil
+	return pageHTML, nil
 }
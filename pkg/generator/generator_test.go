@@ -0,0 +1,55 @@
+package generator
+
+import (
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"static-site-generator/pkg/config"
+)
+
+func TestLanguageResources(t *testing.T) {
+	cfg := &config.Config{
+		ContentDir:      "content",
+		DefaultLanguage: "en",
+		Languages: map[string]config.Language{
+			"en": {},
+			"ka": {ContentDir: "content-ka"},
+			"de": {},
+		},
+	}
+
+	sourcePaths := []string{
+		filepath.Join("content", "index.md"),
+		filepath.Join("content", "blog", "_index.md"),
+		filepath.Join("content", "blog", "trip", "index.md"),
+		filepath.Join("content", "blog", "trip", "index.de.md"),
+		filepath.Join("content", "blog", "post.md"),
+		filepath.Join("content-ka", "blog", "trip", "index.md"),
+	}
+
+	cover := filepath.Join("content", "blog", "trip", "images", "cover.png")
+	logo := filepath.Join("content", "images", "logo.png")
+	diagram := filepath.Join("content", "blog", "diagram.svg")
+	kaCover := filepath.Join("content-ka", "blog", "trip", "cover.png")
+	resourcePaths := []string{cover, logo, diagram, kaCover}
+
+	tests := []struct {
+		name string
+		code string
+		want []string
+	}{
+		{"shouldPublishSharedFilesForDefaultLanguage", "en", []string{cover, logo, diagram}},
+		{"shouldLeaveOutBundleWithoutIndexOfLanguage", "ka", []string{logo, diagram, kaCover}},
+		{"shouldPublishBundleForLanguageOfTranslatedIndex", "de", []string{cover, logo, diagram}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := languageResources(cfg, tt.code, resourcePaths, sourcePaths)
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("languageResources() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,95 @@
+package generator
+
+import (
+	"bytes"
+	"encoding/json"
+	"time"
+
+	"static-site-generator/pkg/site"
+)
+
+const JSON_FEED_VERSION = "https://jsonfeed.org/version/1.1"
+
+// jsonFeed is a feed in JSON Feed 1.1 format, see https://jsonfeed.org/version/1.1.
+type jsonFeed struct {
+	Version     string           `json:"version"`
+	Title       string           `json:"title"`
+	HomePageURL string           `json:"home_page_url"`
+	FeedURL     string           `json:"feed_url"`
+	Description string           `json:"description,omitempty"`
+	Language    string           `json:"language,omitempty"`
+	Authors     []jsonFeedAuthor `json:"authors,omitempty"`
+	Items       []jsonFeedItem   `json:"items"`
+}
+
+type jsonFeedAuthor struct {
+	Name string `json:"name"`
+}
+
+type jsonFeedItem struct {
+	ID            string           `json:"id"`
+	URL           string           `json:"url"`
+	Title         string           `json:"title"`
+	ContentHTML   string           `json:"content_html"`
+	Summary       string           `json:"summary,omitempty"`
+	DatePublished string           `json:"date_published,omitempty"`
+	Tags          []string         `json:"tags,omitempty"`
+	Authors       []jsonFeedAuthor `json:"authors,omitempty"`
+}
+
+// renderJSONFeed renders feed of website as JSON Feed. Items have the whole content of pages
+// (rendered from their Markdown by adapters.MarkdownToHTMLNode) with URLs made absolute, and
+// description front matter as summary. Authors of items are taken from author front matter of
+// pages, the site's author is the author of the feed.
+func renderJSONFeed(website *site.Site, feed *site.Feed) ([]byte, error) {
+	jsonFeed := jsonFeed{
+		Version:     JSON_FEED_VERSION,
+		Title:       feed.Title,
+		HomePageURL: website.BaseURL + feed.Page.URL,
+		FeedURL:     website.BaseURL + feed.JSONFeedURL,
+		Description: feedDescription(feed),
+		Language:    website.LanguageCode,
+		Items:       []jsonFeedItem{},
+	}
+
+	if website.Author != "" {
+		jsonFeed.Authors = []jsonFeedAuthor{{website.Author}}
+	}
+
+	for _, page := range feed.Pages {
+		item := jsonFeedItem{
+			ID:          website.BaseURL + page.URL,
+			URL:         website.BaseURL + page.URL,
+			Title:       page.Title,
+			ContentHTML: absoluteURLs(website.BaseURL, page.URL, string(page.Content)),
+			Summary:     page.Description,
+			Tags:        page.Tags,
+		}
+
+		if item.Summary == "" {
+			item.Summary = page.PlainSummary
+		}
+
+		if !page.Date.IsZero() {
+			item.DatePublished = page.Date.Format(time.RFC3339)
+		}
+
+		if author, err := page.String("author"); err == nil && author != "" {
+			item.Authors = []jsonFeedAuthor{{author}}
+		}
+
+		jsonFeed.Items = append(jsonFeed.Items, item)
+	}
+
+	feedJSON := bytes.Buffer{}
+
+	encoder := json.NewEncoder(&feedJSON)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+
+	if err := encoder.Encode(jsonFeed); err != nil {
+		return nil, err
+	}
+
+	return feedJSON.Bytes(), nil
+}
@@ -0,0 +1,82 @@
+package generator
+
+import (
+	"encoding/json"
+	"html/template"
+	"reflect"
+	"testing"
+	"time"
+
+	"static-site-generator/pkg/config"
+	"static-site-generator/pkg/frontmatter"
+	"static-site-generator/pkg/site"
+)
+
+func TestRenderJSONFeed(t *testing.T) {
+	post := &site.Page{
+		FrontMatter: &frontmatter.FrontMatter{
+			Title:       "Post",
+			Date:        time.Date(2024, 10, 1, 12, 0, 0, 0, time.UTC),
+			Description: "About the post.",
+			Tags:        []string{"go", "tolkien"},
+			Params:      map[string]any{"author": "Nodari"},
+		},
+		Title:   "Post",
+		URL:     "/blog/post/",
+		Content: template.HTML(`<div><p><img alt="map" src="map.png"></img></p></div>`),
+	}
+	undated := &site.Page{
+		FrontMatter: &frontmatter.FrontMatter{Title: "Undated", Params: map[string]any{}},
+		Title:       "Undated",
+		URL:         "/blog/undated/",
+	}
+
+	website := &site.Site{Config: &config.Config{BaseURL: "https://example.com", Author: "Me"}}
+	feed := &site.Feed{
+		Title:       "Blog",
+		Page:        &site.Page{FrontMatter: &frontmatter.FrontMatter{}, URL: "/blog/"},
+		Pages:       []*site.Page{post, undated},
+		JSONFeedURL: "/blog/feed.json",
+	}
+
+	feedJSON, err := renderJSONFeed(website, feed)
+	if err != nil {
+		t.Fatalf("renderJSONFeed() error = %v", err)
+	}
+
+	got := jsonFeed{}
+	if err := json.Unmarshal(feedJSON, &got); err != nil {
+		t.Fatalf("renderJSONFeed() = invalid JSON %s: %v", feedJSON, err)
+	}
+
+	want := jsonFeed{
+		Version:     JSON_FEED_VERSION,
+		Title:       "Blog",
+		HomePageURL: "https://example.com/blog/",
+		FeedURL:     "https://example.com/blog/feed.json",
+		Description: "Recent pages of Blog",
+		Authors:     []jsonFeedAuthor{{"Me"}},
+		Items: []jsonFeedItem{
+			{
+				ID:    "https://example.com/blog/post/",
+				URL:   "https://example.com/blog/post/",
+				Title: "Post",
+				ContentHTML: `<div><p><img alt="map" ` +
+					`src="https://example.com/blog/post/map.png"></img></p></div>`,
+				Summary:       "About the post.",
+				DatePublished: "2024-10-01T12:00:00Z",
+				Tags:          []string{"go", "tolkien"},
+				Authors:       []jsonFeedAuthor{{"Nodari"}},
+			},
+			{
+				ID:    "https://example.com/blog/undated/",
+				URL:   "https://example.com/blog/undated/",
+				Title: "Undated",
+			},
+		},
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("renderJSONFeed() = %s, want %+v", feedJSON, want)
+	}
+}
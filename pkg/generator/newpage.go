@@ -0,0 +1,78 @@
+package generator
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"static-site-generator/pkg/config"
+	"static-site-generator/pkg/site"
+)
+
+// CreatePage writes a draft page with a title made of its file name into pagePath relative to
+// contentDir. pagePath must stay inside contentDir, e.g. ../x.md is rejected.
+func CreatePage(contentDir, pagePath string) error {
+	if !strings.HasSuffix(pagePath, ".md") {
+		pagePath += ".md"
+	}
+
+	destinationPath := filepath.Join(contentDir, pagePath)
+	if !config.IsInsideDir(destinationPath, contentDir) {
+		return fmt.Errorf(
+			"creating page failed, page (%s) must be inside content directory (%s)",
+			pagePath, contentDir,
+		)
+	}
+
+	fmt.Printf("Creating page (%s)...\n", destinationPath)
+
+	_, err := os.Stat(destinationPath)
+	if err == nil {
+		return fmt.Errorf("creating page failed, file (%s) already exists", destinationPath)
+	}
+	if !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("creating page failed, couldn't stat file (%s): %v", destinationPath, err)
+	}
+
+	destinationDir := filepath.Dir(destinationPath)
+
+	err = os.MkdirAll(destinationDir, 0o755)
+	if err != nil {
+		return fmt.Errorf(
+			"creating page failed, couldn't create page's directory (%s): %v", destinationDir, err,
+		)
+	}
+
+	title := titleFromPagePath(pagePath)
+	content := fmt.Sprintf(
+		"---\ntitle: %q\ndate: %s\ndraft: true\n---\n\n# %s\n",
+		title, time.Now().Format(time.RFC3339), title,
+	)
+
+	err = os.WriteFile(destinationPath, []byte(content), 0o644)
+	if err != nil {
+		return fmt.Errorf(
+			"creating page failed, couldn't write to file (%s): %v", destinationPath, err,
+		)
+	}
+
+	fmt.Printf("Created page (%s) successfully!\n", destinationPath)
+
+	return nil
+}
+
+func titleFromPagePath(pagePath string) string {
+	name := strings.TrimSuffix(filepath.Base(pagePath), ".md")
+	if name == "index" {
+		name = filepath.Base(filepath.Dir(pagePath))
+	}
+	if name == "." {
+		return "Home"
+	}
+
+	return site.Titleize(name)
+}
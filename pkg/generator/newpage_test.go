@@ -0,0 +1,85 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestCreatePage(t *testing.T) {
+	tests := []struct {
+		name      string
+		pagePath  string
+		existing  string
+		wantPath  string
+		wantTitle string
+		wantErr   bool
+	}{
+		{
+			name:      "shouldCreateDraftPageWithTitleOfFileName",
+			pagePath:  "blog/first-post",
+			wantPath:  "blog/first-post.md",
+			wantTitle: `title: "First Post"`,
+		},
+		{
+			name:      "shouldTitlePageBundleAfterItsDirectory",
+			pagePath:  "blog/trip/index.md",
+			wantPath:  "blog/trip/index.md",
+			wantTitle: `title: "Trip"`,
+		},
+		{
+			name:     "shouldFailForExistingPage",
+			pagePath: "about.md",
+			existing: "about.md",
+			wantErr:  true,
+		},
+		{
+			name:     "shouldFailForPageOutsideContentDir",
+			pagePath: "../outside.md",
+			wantErr:  true,
+		},
+		{
+			name:     "shouldFailForPageOutsideContentDirAfterCleaning",
+			pagePath: "blog/../../outside",
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			contentDir := filepath.Join(dir, "content")
+
+			if tt.existing != "" {
+				if err := os.MkdirAll(contentDir, 0o755); err != nil {
+					t.Fatalf("MkdirAll() error = %v", err)
+				}
+
+				existingPath := filepath.Join(contentDir, tt.existing)
+				if err := os.WriteFile(existingPath, []byte("# About"), 0o644); err != nil {
+					t.Fatalf("WriteFile() error = %v", err)
+				}
+			}
+
+			err := CreatePage(contentDir, tt.pagePath)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("CreatePage() error = %v, want error %t", err, tt.wantErr)
+			}
+
+			if _, statErr := os.Stat(filepath.Join(dir, "outside.md")); statErr == nil {
+				t.Errorf("CreatePage() wrote outside of content directory")
+			}
+
+			if tt.wantErr {
+				return
+			}
+
+			content, err := os.ReadFile(filepath.Join(contentDir, tt.wantPath))
+			if err != nil || !strings.Contains(string(content), tt.wantTitle) ||
+				!strings.Contains(string(content), "draft: true") {
+				t.Errorf("page = (%s, %v), want a draft with %s", content, err, tt.wantTitle)
+			}
+		})
+	}
+}
@@ -0,0 +1,30 @@
+package generator
+
+import (
+	"fmt"
+	"time"
+
+	"static-site-generator/pkg/config"
+	"static-site-generator/pkg/frontmatter"
+)
+
+type SkippedPage struct {
+	SourcePath string
+	Reason     string
+}
+
+// skipReason returns why page with given front matter shouldn't be published at the moment now,
+// or an empty string if it should be. Drafts, pages dated in the future and expired pages are
+// skipped unless config says otherwise.
+func skipReason(frontMatter *frontmatter.FrontMatter, cfg *config.Config, now time.Time) string {
+	switch {
+	case frontMatter.Draft && !cfg.BuildDrafts:
+		return "draft"
+	case frontMatter.Date.After(now) && !cfg.BuildFuture:
+		return fmt.Sprintf("scheduled for %s", frontMatter.Date.Format(time.RFC3339))
+	case !frontMatter.ExpiryDate.IsZero() && !frontMatter.ExpiryDate.After(now) && !cfg.BuildExpired:
+		return fmt.Sprintf("expired on %s", frontMatter.ExpiryDate.Format(time.RFC3339))
+	default:
+		return ""
+	}
+}
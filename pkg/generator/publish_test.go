@@ -0,0 +1,79 @@
+package generator
+
+import (
+	"testing"
+	"time"
+
+	"static-site-generator/pkg/config"
+	"static-site-generator/pkg/frontmatter"
+)
+
+func TestSkipReason(t *testing.T) {
+	now := time.Date(2024, 10, 1, 12, 0, 0, 0, time.UTC)
+	past := now.Add(-time.Hour)
+	future := now.Add(time.Hour)
+
+	tests := []struct {
+		name        string
+		frontMatter *frontmatter.FrontMatter
+		cfg         *config.Config
+		want        string
+	}{
+		{
+			name:        "shouldPublishPageWithoutFrontMatter",
+			frontMatter: &frontmatter.FrontMatter{},
+			cfg:         &config.Config{},
+			want:        "",
+		},
+		{
+			name:        "shouldPublishPastPageThatExpiresInFuture",
+			frontMatter: &frontmatter.FrontMatter{Date: past, ExpiryDate: future},
+			cfg:         &config.Config{},
+			want:        "",
+		},
+		{
+			name:        "shouldSkipDraft",
+			frontMatter: &frontmatter.FrontMatter{Draft: true, Date: future},
+			cfg:         &config.Config{BuildFuture: true},
+			want:        "draft",
+		},
+		{
+			name:        "shouldPublishDraftWithBuildDrafts",
+			frontMatter: &frontmatter.FrontMatter{Draft: true},
+			cfg:         &config.Config{BuildDrafts: true},
+			want:        "",
+		},
+		{
+			name:        "shouldSkipFuturePage",
+			frontMatter: &frontmatter.FrontMatter{Date: future},
+			cfg:         &config.Config{},
+			want:        "scheduled for 2024-10-01T13:00:00Z",
+		},
+		{
+			name:        "shouldPublishFuturePageWithBuildFuture",
+			frontMatter: &frontmatter.FrontMatter{Date: future},
+			cfg:         &config.Config{BuildFuture: true},
+			want:        "",
+		},
+		{
+			name:        "shouldSkipExpiredPage",
+			frontMatter: &frontmatter.FrontMatter{ExpiryDate: now},
+			cfg:         &config.Config{},
+			want:        "expired on 2024-10-01T12:00:00Z",
+		},
+		{
+			name:        "shouldPublishExpiredPageWithBuildExpired",
+			frontMatter: &frontmatter.FrontMatter{ExpiryDate: past},
+			cfg:         &config.Config{BuildExpired: true},
+			want:        "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := skipReason(tt.frontMatter, tt.cfg, now); got != tt.want {
+				t.Errorf("skipReason() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
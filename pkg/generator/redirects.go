@@ -0,0 +1,150 @@
+package generator
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"html/template"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"static-site-generator/pkg/config"
+	"static-site-generator/pkg/manifest"
+	"static-site-generator/pkg/site"
+)
+
+// REDIRECTS_FILE is the file in destination directory that lists redirects for hosts which
+// support it, one "from to status" rule per line.
+const REDIRECTS_FILE = "_redirects"
+
+var redirectTemplate = template.Must(template.New("redirect").Parse(`<!DOCTYPE html>
+<html lang="{{ .LanguageCode }}">
+<head>
+    <meta charset="utf-8">
+    <title>{{ .Title }}</title>
+    <link rel="canonical" href="{{ .URL }}">
+    <meta name="robots" content="noindex">
+    <meta http-equiv="refresh" content="0; url={{ .URL }}">
+</head>
+<body>
+    <p>This page has moved to <a href="{{ .URL }}">{{ .URL }}</a>.</p>
+</body>
+</html>
+`))
+
+// generateRedirects writes a redirect page for every alias of pages of websites (sites of all
+// languages) and, if enabled by config, the _redirects file listing all of them. Outputs that
+// didn't change since the previous build aren't rewritten. Redirect pages are recorded in the
+// current manifest under the source path of their target page.
+func generateRedirects(
+	cfg *config.Config, websites []*site.Site, previous, current *manifest.Manifest,
+) error {
+	errs := []error{}
+	rules := strings.Builder{}
+
+	for _, alias := range websiteAliases(websites) {
+		targetURL := cfg.BaseURL + alias.Page.URL
+		fmt.Fprintf(&rules, "%s %s 301\n", alias.URL, alias.Page.URL)
+
+		redirectHTML := bytes.Buffer{}
+
+		err := redirectTemplate.Execute(&redirectHTML, map[string]string{
+			"LanguageCode": alias.Page.Language.Code,
+			"Title":        alias.Page.Title,
+			"URL":          targetURL,
+		})
+		if err != nil {
+			errs = append(errs, fmt.Errorf(
+				"generating redirect (%s) of page (%s) failed: %v",
+				alias.URL, alias.Page.SourcePath, err,
+			))
+			continue
+		}
+
+		entry := manifest.PageEntry{
+			SourcePath:      alias.Page.SourcePath,
+			SourceHash:      alias.Page.SourceHash,
+			DestinationPath: alias.DestinationPath,
+		}
+
+		written, err := writeOutput(entry, redirectHTML.Bytes(), previous, current)
+		if err != nil {
+			errs = append(errs, fmt.Errorf(
+				"generating redirect (%s) of page (%s) failed: %v",
+				alias.URL, alias.Page.SourcePath, err,
+			))
+			continue
+		}
+
+		if written {
+			fmt.Printf("Generated redirect from (%s) to (%s).\n", alias.URL, alias.Page.URL)
+		}
+	}
+
+	if cfg.RedirectsFile {
+		entry := manifest.PageEntry{
+			DestinationPath: filepath.Join(cfg.DestinationDir, REDIRECTS_FILE),
+		}
+
+		if _, err := writeOutput(entry, []byte(rules.String()), previous, current); err != nil {
+			errs = append(errs, fmt.Errorf("generating %s file failed: %v", REDIRECTS_FILE, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// websiteAliases returns aliases of websites sorted by URL.
+func websiteAliases(websites []*site.Site) []*site.Alias {
+	aliases := []*site.Alias{}
+	for _, website := range websites {
+		aliases = append(aliases, website.Aliases...)
+	}
+
+	slices.SortFunc(aliases, func(a, b *site.Alias) int { return strings.Compare(a.URL, b.URL) })
+
+	return aliases
+}
+
+// writeOutput writes data to entry's destination path, unless the previous build wrote the same
+// data there and it wasn't modified since, and records entry in the current manifest. It reports
+// whether the file was written.
+func writeOutput(
+	entry manifest.PageEntry, data []byte, previous, current *manifest.Manifest,
+) (bool, error) {
+	entry.OutputHash = manifest.HashBytes(data)
+
+	if previous.Pages[entry.DestinationPath].OutputHash == entry.OutputHash {
+		outputHash, err := manifest.HashFile(entry.DestinationPath)
+		if err != nil {
+			return false, fmt.Errorf(
+				"couldn't hash destination file (%s): %v", entry.DestinationPath, err,
+			)
+		}
+
+		if outputHash == entry.OutputHash {
+			current.Pages[entry.DestinationPath] = entry
+			return false, nil
+		}
+	}
+
+	destinationDir := filepath.Dir(entry.DestinationPath)
+
+	if err := os.MkdirAll(destinationDir, 0o755); err != nil {
+		return false, fmt.Errorf(
+			"couldn't create destination file's directory (%s): %v", destinationDir, err,
+		)
+	}
+
+	if err := os.WriteFile(entry.DestinationPath, data, 0o644); err != nil {
+		return false, fmt.Errorf(
+			"couldn't write to destination file (%s): %v", entry.DestinationPath, err,
+		)
+	}
+
+	current.Pages[entry.DestinationPath] = entry
+
+	return true, nil
+}
@@ -0,0 +1,49 @@
+package generator
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"static-site-generator/pkg/manifest"
+	"static-site-generator/pkg/site"
+)
+
+// copyResources copies resources of website's pages to their destination paths, unless they
+// didn't change since the previous build.
+func copyResources(website *site.Site, previous, current *manifest.Manifest) error {
+	errs := []error{}
+
+	for _, resource := range website.Resources {
+		resourceData, err := os.ReadFile(resource.SourcePath)
+		if err != nil {
+			errs = append(errs, fmt.Errorf(
+				"copying resource (%s) failed, couldn't read it: %v", resource.SourcePath, err,
+			))
+			continue
+		}
+
+		entry := manifest.PageEntry{
+			SourcePath:      resource.SourcePath,
+			SourceHash:      manifest.HashBytes(resourceData),
+			DestinationPath: resource.DestinationPath,
+		}
+
+		written, err := writeOutput(entry, resourceData, previous, current)
+		if err != nil {
+			errs = append(errs, fmt.Errorf(
+				"copying resource (%s) failed: %v", resource.SourcePath, err,
+			))
+			continue
+		}
+
+		if written {
+			fmt.Printf(
+				"Copied resource (%s) to destination (%s).\n",
+				resource.SourcePath, resource.DestinationPath,
+			)
+		}
+	}
+
+	return errors.Join(errs...)
+}
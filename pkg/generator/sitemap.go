@@ -0,0 +1,283 @@
+package generator
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"static-site-generator/pkg/config"
+	"static-site-generator/pkg/manifest"
+	"static-site-generator/pkg/site"
+)
+
+const (
+	SITEMAP_FILE      = "sitemap.xml"
+	ROBOTS_FILE       = "robots.txt"
+	SITEMAP_NAMESPACE = "http://www.sitemaps.org/schemas/sitemap/0.9"
+	// SITEMAP_MAX_URLS is the number of URLs one sitemap file may list, larger sites get split
+	// sitemaps listed by a sitemap index.
+	SITEMAP_MAX_URLS = 50000
+)
+
+// CHANGE_FREQUENCIES are the values changefreq front matter may have.
+var CHANGE_FREQUENCIES = []string{
+	"always", "hourly", "daily", "weekly", "monthly", "yearly", "never",
+}
+
+type sitemapURLSet struct {
+	XMLName xml.Name     `xml:"urlset"`
+	XMLNS   string       `xml:"xmlns,attr"`
+	URLs    []sitemapURL `xml:"url"`
+}
+
+type sitemapURL struct {
+	Loc        string `xml:"loc"`
+	LastMod    string `xml:"lastmod,omitempty"`
+	ChangeFreq string `xml:"changefreq,omitempty"`
+	Priority   string `xml:"priority,omitempty"`
+}
+
+type sitemapIndex struct {
+	XMLName  xml.Name     `xml:"sitemapindex"`
+	XMLNS    string       `xml:"xmlns,attr"`
+	Sitemaps []sitemapRef `xml:"sitemap"`
+}
+
+type sitemapRef struct {
+	Loc     string `xml:"loc"`
+	LastMod string `xml:"lastmod,omitempty"`
+}
+
+// sitemapFile is a rendered sitemap (or sitemap index) file, named relative to destination
+// directory.
+type sitemapFile struct {
+	name string
+	data []byte
+}
+
+// generateSitemap writes sitemap.xml listing pages of websites (sites of all languages) and
+// robots.txt referencing it, unless static directory has files with the same names. Pages with
+// noindex front matter aren't listed. They aren't disallowed by robots.txt, whose rules are URL
+// prefixes (so that e.g. a noindex section would hide its pages) and which would keep crawlers
+// from seeing the noindex meta tag of the page. Sites with more than
+// SITEMAP_MAX_URLS pages get a sitemap index in sitemap.xml listing sitemap-1.xml, sitemap-2.xml
+// and so on. Pages with invalid sitemap front matter are left out of the sitemap. Sites without
+// baseURL get no sitemap, since it needs absolute URLs, but still get robots.txt.
+func generateSitemap(
+	cfg *config.Config, websites []*site.Site, previous, current *manifest.Manifest,
+) error {
+	urls, errs := sitemapURLs(cfg, websites)
+
+	if _, found := current.StaticFiles[SITEMAP_FILE]; !found && cfg.BaseURL != "" {
+		files, err := renderSitemaps(cfg.BaseURL, urls, SITEMAP_MAX_URLS)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("generating sitemap failed: %v", err))
+		}
+
+		for _, file := range files {
+			entry := manifest.PageEntry{
+				DestinationPath: filepath.Join(cfg.DestinationDir, file.name),
+			}
+
+			written, err := writeOutput(entry, file.data, previous, current)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("generating sitemap (%s) failed: %v", file.name, err))
+				continue
+			}
+
+			if written {
+				fmt.Printf("Generated sitemap (%s).\n", entry.DestinationPath)
+			}
+		}
+	}
+
+	if _, found := current.StaticFiles[ROBOTS_FILE]; !found {
+		entry := manifest.PageEntry{DestinationPath: filepath.Join(cfg.DestinationDir, ROBOTS_FILE)}
+		robots := renderRobots(cfg.BaseURL)
+
+		if _, err := writeOutput(entry, robots, previous, current); err != nil {
+			errs = append(errs, fmt.Errorf("generating %s file failed: %v", ROBOTS_FILE, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// sitemapURLs returns sorted sitemap URLs of pages of websites without noindex front matter.
+// Pages of paginated list pages after the first one aren't listed.
+func sitemapURLs(cfg *config.Config, websites []*site.Site) ([]sitemapURL, []error) {
+	urls := []sitemapURL{}
+	errs := []error{}
+
+	for _, website := range websites {
+		for _, page := range slices.Concat(website.Pages, website.ListPages) {
+			if page.Paginator != nil && page.Paginator.PageNumber > 1 {
+				continue
+			}
+
+			noindex, err := page.Bool("noindex")
+			if err != nil {
+				errs = append(errs, fmt.Errorf(
+					"generating sitemap entry of page (%s) failed: %v", page.SourcePath, err,
+				))
+				continue
+			}
+
+			if noindex {
+				continue
+			}
+
+			url, err := newSitemapURL(cfg, page)
+			if err != nil {
+				errs = append(errs, fmt.Errorf(
+					"generating sitemap entry of page (%s) failed: %v", page.SourcePath, err,
+				))
+				continue
+			}
+
+			urls = append(urls, url)
+		}
+	}
+
+	slices.SortFunc(urls, func(a, b sitemapURL) int { return strings.Compare(a.Loc, b.Loc) })
+
+	return urls, errs
+}
+
+// newSitemapURL returns the sitemap URL of page, with its lastmod, changefreq and priority front
+// matter.
+func newSitemapURL(cfg *config.Config, page *site.Page) (sitemapURL, error) {
+	url := sitemapURL{Loc: cfg.BaseURL + page.URL}
+
+	lastMod, err := pageLastMod(page)
+	if err != nil {
+		return url, err
+	}
+
+	if !lastMod.IsZero() {
+		url.LastMod = lastMod.UTC().Format(time.RFC3339)
+	}
+
+	url.ChangeFreq, err = page.String("changefreq")
+	if err != nil {
+		return url, err
+	}
+
+	if url.ChangeFreq != "" && !slices.Contains(CHANGE_FREQUENCIES, url.ChangeFreq) {
+		return url, fmt.Errorf(
+			"changefreq (%s) must be one of %s",
+			url.ChangeFreq, strings.Join(CHANGE_FREQUENCIES, ", "),
+		)
+	}
+
+	if _, found := page.Params["priority"]; found {
+		priority, err := page.Float("priority")
+		if err != nil {
+			return url, err
+		}
+
+		if priority < 0 || priority > 1 {
+			return url, fmt.Errorf("priority (%v) must be between 0 and 1", priority)
+		}
+
+		url.Priority = strconv.FormatFloat(priority, 'f', -1, 64)
+	}
+
+	return url, nil
+}
+
+// pageLastMod returns when page was last modified: its lastmod or date front matter, or else the
+// newest modification time of pages it lists, or else modification time of its source file. It
+// is zero if none of them are known, e.g. for generated list pages of taxonomies.
+func pageLastMod(page *site.Page) (time.Time, error) {
+	lastMod, err := page.Time("lastmod")
+	if err != nil || !lastMod.IsZero() {
+		return lastMod, err
+	}
+
+	if !page.Date.IsZero() {
+		return page.Date, nil
+	}
+
+	for _, listedPage := range page.Pages {
+		listedLastMod, err := pageLastMod(listedPage)
+		if err != nil {
+			return time.Time{}, err
+		}
+
+		if listedLastMod.After(lastMod) {
+			lastMod = listedLastMod
+		}
+	}
+
+	if !lastMod.IsZero() {
+		return lastMod, nil
+	}
+
+	info, err := os.Stat(page.SourcePath)
+	if err != nil || !info.Mode().IsRegular() {
+		return time.Time{}, nil
+	}
+
+	return info.ModTime(), nil
+}
+
+// renderSitemaps renders urls into sitemap.xml, or, if there are more than maxURLs of them, into
+// sitemap-1.xml, sitemap-2.xml and so on of at most maxURLs each, and a sitemap index listing
+// them into sitemap.xml.
+func renderSitemaps(baseURL string, urls []sitemapURL, maxURLs int) ([]sitemapFile, error) {
+	if len(urls) <= maxURLs {
+		data, err := marshalFeed(sitemapURLSet{XMLNS: SITEMAP_NAMESPACE, URLs: urls})
+		if err != nil {
+			return nil, err
+		}
+
+		return []sitemapFile{{SITEMAP_FILE, data}}, nil
+	}
+
+	files := []sitemapFile{}
+	index := sitemapIndex{XMLNS: SITEMAP_NAMESPACE, Sitemaps: []sitemapRef{}}
+
+	for chunk := range slices.Chunk(urls, maxURLs) {
+		name := fmt.Sprintf("sitemap-%d.xml", len(files)+1)
+
+		data, err := marshalFeed(sitemapURLSet{XMLNS: SITEMAP_NAMESPACE, URLs: chunk})
+		if err != nil {
+			return nil, err
+		}
+
+		ref := sitemapRef{Loc: baseURL + "/" + name}
+		for _, url := range chunk {
+			ref.LastMod = max(ref.LastMod, url.LastMod)
+		}
+
+		files = append(files, sitemapFile{name, data})
+		index.Sitemaps = append(index.Sitemaps, ref)
+	}
+
+	data, err := marshalFeed(index)
+	if err != nil {
+		return nil, err
+	}
+
+	return append([]sitemapFile{{SITEMAP_FILE, data}}, files...), nil
+}
+
+// renderRobots renders robots.txt that allows everything to all crawlers and references the
+// sitemap, unless baseURL is empty.
+func renderRobots(baseURL string) []byte {
+	robots := strings.Builder{}
+	robots.WriteString("User-agent: *\nDisallow:\n")
+
+	if baseURL != "" {
+		fmt.Fprintf(&robots, "\nSitemap: %s/%s\n", baseURL, SITEMAP_FILE)
+	}
+
+	return []byte(robots.String())
+}
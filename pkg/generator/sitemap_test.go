@@ -0,0 +1,233 @@
+package generator
+
+import (
+	"encoding/xml"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"static-site-generator/pkg/config"
+	"static-site-generator/pkg/frontmatter"
+	"static-site-generator/pkg/manifest"
+	"static-site-generator/pkg/site"
+)
+
+func TestNewSitemapURL(t *testing.T) {
+	cfg := &config.Config{BaseURL: "https://example.com"}
+	date := time.Date(2024, 10, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		date    time.Time
+		params  map[string]any
+		pages   []*site.Page
+		want    sitemapURL
+		wantErr bool
+	}{
+		{
+			name:   "shouldUseDateAndSitemapFrontMatter",
+			date:   date,
+			params: map[string]any{"changefreq": "weekly", "priority": 0.8},
+			want: sitemapURL{
+				Loc:        "https://example.com/blog/post/",
+				LastMod:    "2024-10-01T00:00:00Z",
+				ChangeFreq: "weekly",
+				Priority:   "0.8",
+			},
+		},
+		{
+			name:   "shouldPreferLastmodFrontMatter",
+			date:   date,
+			params: map[string]any{"lastmod": date.AddDate(0, 1, 0), "priority": 1},
+			want: sitemapURL{
+				Loc:      "https://example.com/blog/post/",
+				LastMod:  "2024-11-01T00:00:00Z",
+				Priority: "1",
+			},
+		},
+		{
+			name:   "shouldUseNewestListedPage",
+			params: map[string]any{},
+			pages: []*site.Page{
+				{FrontMatter: &frontmatter.FrontMatter{Date: date, Params: map[string]any{}}},
+				{FrontMatter: &frontmatter.FrontMatter{
+					Date: date.AddDate(0, 0, 2), Params: map[string]any{},
+				}},
+			},
+			want: sitemapURL{Loc: "https://example.com/blog/post/", LastMod: "2024-10-03T00:00:00Z"},
+		},
+		{
+			name:   "shouldOmitUnknownLastmod",
+			params: map[string]any{},
+			want:   sitemapURL{Loc: "https://example.com/blog/post/"},
+		},
+		{
+			name:    "shouldRejectUnknownChangefreq",
+			params:  map[string]any{"changefreq": "sometimes"},
+			wantErr: true,
+		},
+		{
+			name:    "shouldRejectPriorityAboveOne",
+			params:  map[string]any{"priority": 2},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			page := &site.Page{
+				FrontMatter: &frontmatter.FrontMatter{Date: tt.date, Params: tt.params},
+				URL:         "/blog/post/",
+				SourcePath:  "content/blog/missing.md",
+				Pages:       tt.pages,
+			}
+
+			got, err := newSitemapURL(cfg, page)
+			if (err != nil) != tt.wantErr || (!tt.wantErr && got != tt.want) {
+				t.Errorf("newSitemapURL() = %+v, %v, want %+v, error %t", got, err, tt.want, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRenderSitemaps(t *testing.T) {
+	urls := []sitemapURL{
+		{Loc: "https://example.com/", LastMod: "2024-10-01T00:00:00Z"},
+		{Loc: "https://example.com/a/", LastMod: "2024-10-03T00:00:00Z"},
+		{Loc: "https://example.com/b/"},
+	}
+
+	files, err := renderSitemaps("https://example.com", urls, 3)
+	if err != nil || len(files) != 1 || files[0].name != SITEMAP_FILE {
+		t.Fatalf("renderSitemaps() = %d file(s), %v, want one %s", len(files), err, SITEMAP_FILE)
+	}
+
+	urlSet := sitemapURLSet{}
+	if err := xml.Unmarshal(files[0].data, &urlSet); err != nil || len(urlSet.URLs) != 3 {
+		t.Errorf("renderSitemaps() = %s, want 3 URLs", files[0].data)
+	}
+
+	files, err = renderSitemaps("https://example.com", urls, 2)
+	if err != nil || len(files) != 3 {
+		t.Fatalf("renderSitemaps() = %d file(s), %v, want 3", len(files), err)
+	}
+
+	index := sitemapIndex{}
+	if err := xml.Unmarshal(files[0].data, &index); err != nil {
+		t.Fatalf("renderSitemaps() = invalid XML %s: %v", files[0].data, err)
+	}
+
+	want := []sitemapRef{
+		{Loc: "https://example.com/sitemap-1.xml", LastMod: "2024-10-03T00:00:00Z"},
+		{Loc: "https://example.com/sitemap-2.xml"},
+	}
+
+	if files[0].name != SITEMAP_FILE || files[1].name != "sitemap-1.xml" ||
+		files[2].name != "sitemap-2.xml" || len(index.Sitemaps) != 2 ||
+		index.Sitemaps[0] != want[0] || index.Sitemaps[1] != want[1] {
+		t.Errorf("renderSitemaps() index = %s", files[0].data)
+	}
+}
+
+func TestRenderRobots(t *testing.T) {
+	tests := []struct {
+		name    string
+		baseURL string
+		want    string
+	}{
+		{
+			name:    "shouldAllowEverythingAndReferenceSitemap",
+			baseURL: "https://example.com",
+			want:    "User-agent: *\nDisallow:\n\nSitemap: https://example.com/sitemap.xml\n",
+		},
+		{
+			name: "shouldLeaveOutSitemapWithoutBaseURL",
+			want: "User-agent: *\nDisallow:\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := string(renderRobots(tt.baseURL)); got != tt.want {
+				t.Errorf("renderRobots() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGenerateSitemap_Noindex(t *testing.T) {
+	newPage := func(url string, noindex bool) *site.Page {
+		return &site.Page{
+			FrontMatter: &frontmatter.FrontMatter{Params: map[string]any{"noindex": noindex}},
+			URL:         url,
+		}
+	}
+
+	tests := []struct {
+		name     string
+		home     *site.Page
+		blog     *site.Page
+		wantLocs []string
+	}{
+		{
+			name: "shouldOnlyLeaveOutNoindexHomePage",
+			home: newPage("/", true),
+			blog: newPage("/blog/", false),
+			wantLocs: []string{
+				"https://example.com/blog/", "https://example.com/blog/post/",
+			},
+		},
+		{
+			name: "shouldKeepPagesOfNoindexSection",
+			home: newPage("/", false),
+			blog: newPage("/blog/", true),
+			wantLocs: []string{
+				"https://example.com/", "https://example.com/blog/post/",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &config.Config{BaseURL: "https://example.com", DestinationDir: t.TempDir()}
+			website := &site.Site{
+				Config:    cfg,
+				Pages:     []*site.Page{tt.home, newPage("/blog/post/", false)},
+				ListPages: []*site.Page{tt.blog},
+			}
+			previous := manifest.New(cfg.DestinationDir, "")
+			current := manifest.New(cfg.DestinationDir, "")
+
+			if err := generateSitemap(cfg, []*site.Site{website}, previous, current); err != nil {
+				t.Fatalf("generateSitemap() error = %v", err)
+			}
+
+			robots, err := os.ReadFile(filepath.Join(cfg.DestinationDir, ROBOTS_FILE))
+			wantRobots := "User-agent: *\nDisallow:\n\nSitemap: https://example.com/sitemap.xml\n"
+			if err != nil || string(robots) != wantRobots {
+				t.Errorf("robots.txt = %q, %v, want %q", robots, err, wantRobots)
+			}
+
+			sitemap, err := os.ReadFile(filepath.Join(cfg.DestinationDir, SITEMAP_FILE))
+			if err != nil {
+				t.Fatalf("ReadFile() error = %v", err)
+			}
+
+			urlSet := sitemapURLSet{}
+			if err := xml.Unmarshal(sitemap, &urlSet); err != nil {
+				t.Fatalf("sitemap = invalid XML %s: %v", sitemap, err)
+			}
+
+			locs := []string{}
+			for _, url := range urlSet.URLs {
+				locs = append(locs, url.Loc)
+			}
+
+			if !slices.Equal(locs, tt.wantLocs) {
+				t.Errorf("sitemap locations = %v, want %v", locs, tt.wantLocs)
+			}
+		})
+	}
+}
@@ -0,0 +1,76 @@
+package generator
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"static-site-generator/pkg/config"
+	"static-site-generator/pkg/fileutils"
+	"static-site-generator/pkg/manifest"
+)
+
+// syncStaticFiles copies files of static directory that changed since the previous build into
+// destination directory and records their hashes in the current manifest.
+func syncStaticFiles(cfg *config.Config, previous, current *manifest.Manifest) error {
+	fmt.Printf(
+		"Copying changed files of static directory (%s) into destination directory (%s)...\n",
+		cfg.StaticDir, cfg.DestinationDir,
+	)
+
+	copiedFilesCount := 0
+
+	handleWalkDirEntry := func(sourcePath string, entry os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if entry.IsDir() {
+			return nil
+		}
+
+		relativePath, err := filepath.Rel(cfg.StaticDir, sourcePath)
+		if err != nil {
+			return err
+		}
+
+		destinationPath := filepath.Join(cfg.DestinationDir, relativePath)
+
+		sourceHash, err := manifest.HashFile(sourcePath)
+		if err != nil {
+			return fmt.Errorf("couldn't hash static file (%s): %v", sourcePath, err)
+		}
+
+		current.StaticFiles[relativePath] = sourceHash
+
+		if previous.StaticFiles[relativePath] == sourceHash {
+			destinationHash, err := manifest.HashFile(destinationPath)
+			if err != nil {
+				return fmt.Errorf("couldn't hash destination file (%s): %v", destinationPath, err)
+			}
+
+			if destinationHash == sourceHash {
+				return nil
+			}
+		}
+
+		copiedFilesCount++
+
+		return fileutils.CopyFile(sourcePath, destinationPath)
+	}
+
+	err := filepath.WalkDir(cfg.StaticDir, handleWalkDirEntry)
+	if err != nil {
+		return fmt.Errorf(
+			"copying contents of static directory (%s) into destination directory (%s) failed: %v",
+			cfg.StaticDir, cfg.DestinationDir, err,
+		)
+	}
+
+	fmt.Printf(
+		"Copied %d changed file(s) of static directory (%s) into destination directory (%s) successfully!\n",
+		copiedFilesCount, cfg.StaticDir, cfg.DestinationDir,
+	)
+
+	return nil
+}
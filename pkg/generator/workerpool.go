@@ -0,0 +1,38 @@
+package generator
+
+import (
+	"runtime"
+	"sync"
+)
+
+// runWorkerPool calls job with every index in [0, jobsCount) using at most workers goroutines.
+// Non-positive workers means GOMAXPROCS goroutines. It returns after all jobs are done.
+func runWorkerPool(workers, jobsCount int, job func(i int)) {
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+
+	workers = min(workers, jobsCount)
+
+	indices := make(chan int)
+	var waitGroup sync.WaitGroup
+
+	for range workers {
+		waitGroup.Add(1)
+
+		go func() {
+			defer waitGroup.Done()
+
+			for i := range indices {
+				job(i)
+			}
+		}()
+	}
+
+	for i := range jobsCount {
+		indices <- i
+	}
+
+	close(indices)
+	waitGroup.Wait()
+}
@@ -0,0 +1,58 @@
+package generator
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRunWorkerPool(t *testing.T) {
+	tests := []struct {
+		name           string
+		workers        int
+		jobsCount      int
+		wantMaxRunning int32
+	}{
+		{name: "shouldDoNothingWithoutJobs", workers: 4, jobsCount: 0, wantMaxRunning: 0},
+		{name: "shouldRunJobsOneByOne", workers: 1, jobsCount: 5, wantMaxRunning: 1},
+		{name: "shouldNotExceedWorkers", workers: 3, jobsCount: 20, wantMaxRunning: 3},
+		{name: "shouldNotExceedJobsCount", workers: 8, jobsCount: 2, wantMaxRunning: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var running, maxRunning atomic.Int32
+			var mutex sync.Mutex
+			done := make([]int, tt.jobsCount)
+
+			runWorkerPool(tt.workers, tt.jobsCount, func(i int) {
+				current := running.Add(1)
+				for {
+					previous := maxRunning.Load()
+					if current <= previous || maxRunning.CompareAndSwap(previous, current) {
+						break
+					}
+				}
+
+				time.Sleep(time.Millisecond)
+
+				mutex.Lock()
+				done[i]++
+				mutex.Unlock()
+
+				running.Add(-1)
+			})
+
+			for i, count := range done {
+				if count != 1 {
+					t.Errorf("runWorkerPool() ran job %d %d times, want 1", i, count)
+				}
+			}
+
+			if got := maxRunning.Load(); got > tt.wantMaxRunning {
+				t.Errorf("runWorkerPool() ran %d jobs at once, want at most %d", got, tt.wantMaxRunning)
+			}
+		})
+	}
+}
@@ -0,0 +1,10 @@
+package manifest
+
+import "errors"
+
+const MANIFEST_VERSION = 2
+
+var (
+	ErrManifestNotFound     = errors.New("manifest not found")
+	ErrIncompatibleManifest = errors.New("manifest was written by an incompatible version")
+)
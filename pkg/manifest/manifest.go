@@ -0,0 +1,115 @@
+package manifest
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+)
+
+// Manifest records hashes of sources, layouts and outputs of a build, so that the next build
+// can skip pages and static files that didn't change since.
+type Manifest struct {
+	Version        int    `json:"version"`
+	DestinationDir string `json:"destinationDir"`
+	ConfigHash     string `json:"configHash"`
+	// SiteHash changes whenever data of any page that other pages can use changes.
+	SiteHash string `json:"siteHash"`
+
+	// Pages are keyed by destination file path, since a source can have several outputs, e.g.
+	// pages of a paginated list.
+	Pages map[string]PageEntry `json:"pages"`
+	// StaticFiles are keyed by file path relative to static directory, values are content hashes.
+	StaticFiles map[string]string `json:"staticFiles"`
+}
+
+type PageEntry struct {
+	SourcePath      string `json:"sourcePath"`
+	SourceHash      string `json:"sourceHash"`
+	LayoutHash      string `json:"layoutHash"`
+	DestinationPath string `json:"destinationPath"`
+	OutputHash      string `json:"outputHash"`
+}
+
+func New(destinationDir, configHash string) *Manifest {
+	return &Manifest{
+		Version:        MANIFEST_VERSION,
+		DestinationDir: destinationDir,
+		ConfigHash:     configHash,
+		Pages:          map[string]PageEntry{},
+		StaticFiles:    map[string]string{},
+	}
+}
+
+// Load reads manifest from path. It returns ErrManifestNotFound if the file doesn't exist and
+// ErrIncompatibleManifest if the file was written by a different version of the generator.
+func Load(path string) (*Manifest, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, ErrManifestNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("loading manifest failed, couldn't read file (%s): %v", path, err)
+	}
+
+	manifest := &Manifest{}
+	if err := json.Unmarshal(data, manifest); err != nil {
+		return nil, fmt.Errorf("loading manifest failed, invalid file (%s): %v", path, err)
+	}
+
+	if manifest.Version != MANIFEST_VERSION {
+		return nil, ErrIncompatibleManifest
+	}
+
+	if manifest.Pages == nil {
+		manifest.Pages = map[string]PageEntry{}
+	}
+
+	if manifest.StaticFiles == nil {
+		manifest.StaticFiles = map[string]string{}
+	}
+
+	return manifest, nil
+}
+
+func (manifest *Manifest) Save(path string) error {
+	data, err := json.MarshalIndent(manifest, "", "    ")
+	if err != nil {
+		return fmt.Errorf("saving manifest failed: %v", err)
+	}
+
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return fmt.Errorf("saving manifest failed, couldn't write file (%s): %v", path, err)
+	}
+
+	return nil
+}
+
+func HashBytes(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// HashFile returns content hash of the file at path, or an empty string if it doesn't exist.
+func HashFile(path string) (string, error) {
+	file, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+
+	defer file.Close()
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, file); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
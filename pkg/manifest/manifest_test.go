@@ -0,0 +1,120 @@
+package manifest
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestLoad(t *testing.T) {
+	dir := t.TempDir()
+
+	saved := New("public", "config-hash")
+	saved.SiteHash = "site-hash"
+	saved.Pages["public/index.html"] = PageEntry{
+		SourcePath:      "content/index.md",
+		SourceHash:      "source-hash",
+		LayoutHash:      "layout-hash",
+		DestinationPath: "public/index.html",
+		OutputHash:      "output-hash",
+	}
+	saved.StaticFiles["index.css"] = "css-hash"
+
+	savedPath := filepath.Join(dir, "saved.json")
+	if err := saved.Save(savedPath); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	writeFile := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatalf("WriteFile() error = %v", err)
+		}
+
+		return path
+	}
+
+	tests := []struct {
+		name         string
+		path         string
+		wantManifest *Manifest
+		wantErr      error
+		wantAnyErr   bool
+	}{
+		{
+			name:         "shouldLoadSavedManifest",
+			path:         savedPath,
+			wantManifest: saved,
+		},
+		{
+			name: "shouldDefaultMissingMaps",
+			path: writeFile("empty.json", `{"version": 2, "destinationDir": "public"}`),
+			wantManifest: &Manifest{
+				Version:        MANIFEST_VERSION,
+				DestinationDir: "public",
+				Pages:          map[string]PageEntry{},
+				StaticFiles:    map[string]string{},
+			},
+		},
+		{
+			name:    "shouldReturnErrManifestNotFound",
+			path:    filepath.Join(dir, "missing.json"),
+			wantErr: ErrManifestNotFound,
+		},
+		{
+			name:    "shouldReturnErrIncompatibleManifest",
+			path:    writeFile("old.json", `{"version": 1}`),
+			wantErr: ErrIncompatibleManifest,
+		},
+		{
+			name:       "shouldFailForInvalidFile",
+			path:       writeFile("invalid.json", `{"version":`),
+			wantAnyErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Load(tt.path)
+			if tt.wantAnyErr {
+				if err == nil {
+					t.Errorf("Load() error = nil, want an error")
+				}
+				return
+			}
+
+			if !errors.Is(err, tt.wantErr) || !reflect.DeepEqual(got, tt.wantManifest) {
+				t.Errorf("Load() = (%+v, %v), want (%+v, %v)", got, err, tt.wantManifest, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestHashFile(t *testing.T) {
+	dir := t.TempDir()
+
+	path := filepath.Join(dir, "index.html")
+	if err := os.WriteFile(path, []byte("<p>Hi</p>"), 0o644); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+
+	tests := []struct {
+		name string
+		path string
+		want string
+	}{
+		{"shouldHashContentOfFile", path, HashBytes([]byte("<p>Hi</p>"))},
+		{"shouldReturnEmptyHashForMissingFile", filepath.Join(dir, "missing.html"), ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := HashFile(tt.path)
+			if err != nil || got != tt.want {
+				t.Errorf("HashFile() = (%s, %v), want (%s, nil)", got, err, tt.want)
+			}
+		})
+	}
+}
@@ -1,15 +1,24 @@
 package server
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"net/http"
 )
 
-func ServeDir(destinationDir, serverPort string) error {
+// ServeDir serves destination directory until ctx is done, then shuts the server down gracefully.
+func ServeDir(ctx context.Context, destinationDir, serverPort string) error {
 	fmt.Printf("Serving %s directory, listening on %s port.\n", destinationDir, serverPort)
 
-	err := http.ListenAndServe(serverPort, http.FileServer(http.Dir(destinationDir)))
+	server := &http.Server{Addr: serverPort, Handler: http.FileServer(http.Dir(destinationDir))}
+
+	stopShutdown := context.AfterFunc(ctx, func() {
+		_ = server.Shutdown(context.Background())
+	})
+	defer stopShutdown()
+
+	err := server.ListenAndServe()
 	if errors.Is(err, http.ErrServerClosed) {
 		fmt.Println("Shutting down server.")
 		return nil
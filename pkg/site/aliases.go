@@ -0,0 +1,153 @@
+package site
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"slices"
+	"strings"
+
+	"static-site-generator/pkg/config"
+)
+
+// Alias is an old URL of a page, e.g. from before the page was moved, that redirects to the
+// page's current URL.
+type Alias struct {
+	URL             string
+	DestinationPath string
+	Page            *Page
+}
+
+// addAliases collects aliases front matter of pages into site.Aliases. Aliases of pages of languages
+// other than the default one get the language's prefix. It fails if an alias is invalid or has the
+// URL of a page in pagesByURL or of another alias.
+func (site *Site) addAliases(cfg *config.Config, pages []*Page, pagesByURL map[string]*Page) error {
+	errs := []error{}
+	aliasesByURL := map[string]*Alias{}
+
+	for _, page := range pages {
+		aliases, err := page.Strings("aliases")
+		if err != nil {
+			errs = append(errs, fmt.Errorf("page (%s): %w", page.SourcePath, err))
+			continue
+		}
+
+		for _, value := range aliases {
+			url, err := aliasURL(page.dir, value)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("page (%s): %w", page.SourcePath, err))
+				continue
+			}
+
+			url = cfg.LanguagePrefix + url
+
+			if other, found := pagesByURL[url]; found {
+				errs = append(errs, fmt.Errorf(
+					"%w: alias (%s) of page (%s) is the URL of page (%s)",
+					ErrAliasCollision, value, page.SourcePath, other.SourcePath,
+				))
+				continue
+			}
+
+			if other, found := aliasesByURL[url]; found {
+				errs = append(errs, fmt.Errorf(
+					"%w: pages (%s) and (%s) have alias (%s)",
+					ErrAliasCollision, other.Page.SourcePath, page.SourcePath, url,
+				))
+				continue
+			}
+
+			alias := &Alias{
+				URL:             url,
+				DestinationPath: urlToDestinationPath(cfg, url),
+				Page:            page,
+			}
+
+			aliasesByURL[url] = alias
+			site.Aliases = append(site.Aliases, alias)
+		}
+	}
+
+	slices.SortFunc(site.Aliases, func(a, b *Alias) int { return strings.Compare(a.URL, b.URL) })
+
+	return errors.Join(errs...)
+}
+
+// aliasURL returns URL of alias of a page in directory dir (slash separated and relative to
+// content directory). Aliases starting with / are relative to the site root, others to dir.
+// Aliases not ending with .html are directories, e.g. "old-post" becomes /dir/old-post/.
+func aliasURL(dir, alias string) (string, error) {
+	alias = strings.TrimSpace(alias)
+
+	if alias == "" || strings.ContainsAny(alias, ":?#\\") || strings.HasPrefix(alias, "//") {
+		return "", fmt.Errorf("%w (%s), expected a path like /old/post/", ErrInvalidAlias, alias)
+	}
+
+	if !strings.HasPrefix(alias, "/") {
+		alias = path.Join("/", dir, alias)
+	}
+
+	url := path.Clean(alias)
+	if url != "/" && !strings.HasSuffix(url, ".html") {
+		url += "/"
+	}
+
+	return url, nil
+}
+
+// CheckAliases fails with ErrAliasCollision if an alias of one of sites, which are sites of all
+// languages, is the URL of a page or another alias of any of them, or is generated into the
+// destination path of a resource or of one of staticPaths (destination paths of static files).
+// addAliases only sees pages of its own site.
+func CheckAliases(sites []*Site, staticPaths []string) error {
+	errs := []error{}
+	pagesByURL := map[string]*Page{}
+	aliasesByURL := map[string]*Alias{}
+	sourcesByDestinationPath := map[string]string{}
+
+	for _, staticPath := range staticPaths {
+		sourcesByDestinationPath[staticPath] = "static file"
+	}
+
+	for _, site := range sites {
+		for _, page := range slices.Concat(site.Pages, site.ListPages) {
+			pagesByURL[page.URL] = page
+		}
+
+		for _, resource := range site.Resources {
+			sourcesByDestinationPath[resource.DestinationPath] = "resource " + resource.SourcePath
+		}
+	}
+
+	for _, site := range sites {
+		for _, alias := range site.Aliases {
+			if other, found := pagesByURL[alias.URL]; found {
+				errs = append(errs, fmt.Errorf(
+					"%w: alias (%s) of page (%s) is the URL of page (%s)",
+					ErrAliasCollision, alias.URL, alias.Page.SourcePath, other.SourcePath,
+				))
+				continue
+			}
+
+			if source, found := sourcesByDestinationPath[alias.DestinationPath]; found {
+				errs = append(errs, fmt.Errorf(
+					"%w: alias (%s) of page (%s) and %s are generated into (%s)",
+					ErrAliasCollision, alias.URL, alias.Page.SourcePath, source, alias.DestinationPath,
+				))
+				continue
+			}
+
+			if other, found := aliasesByURL[alias.URL]; found {
+				errs = append(errs, fmt.Errorf(
+					"%w: pages (%s) and (%s) have alias (%s)",
+					ErrAliasCollision, other.Page.SourcePath, alias.Page.SourcePath, alias.URL,
+				))
+				continue
+			}
+
+			aliasesByURL[alias.URL] = alias
+		}
+	}
+
+	return errors.Join(errs...)
+}
@@ -0,0 +1,151 @@
+package site
+
+import (
+	"errors"
+	"testing"
+
+	"static-site-generator/pkg/config"
+	"static-site-generator/pkg/frontmatter"
+)
+
+func TestAliasURL(t *testing.T) {
+	tests := []struct {
+		name    string
+		dir     string
+		alias   string
+		want    string
+		wantErr error
+	}{
+		{"shouldKeepAbsoluteDirectoryAlias", "blog", "/old/post/", "/old/post/", nil},
+		{"shouldKeepHTMLAlias", "blog", "/old/post.html", "/old/post.html", nil},
+		{"shouldAddTrailingSlash", "blog", "/old/post", "/old/post/", nil},
+		{"shouldResolveRelativeAlias", "blog", "old-post", "/blog/old-post/", nil},
+		{"shouldCleanAlias", "blog", "../old//post.html", "/old/post.html", nil},
+		{"shouldFailForEmptyAlias", "blog", " ", "", ErrInvalidAlias},
+		{"shouldFailForAbsoluteURL", "blog", "https://example.com/old/", "", ErrInvalidAlias},
+		{"shouldFailForQuery", "blog", "/old/?page=2", "", ErrInvalidAlias},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := aliasURL(tt.dir, tt.alias)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("aliasURL() error = %v, want %v", err, tt.wantErr)
+			}
+
+			if got != tt.want {
+				t.Errorf("aliasURL() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNew_Aliases(t *testing.T) {
+	cfg := &config.Config{DestinationDir: "public"}
+
+	pages := []*Page{
+		newTestPage("blog/post.md", "/blog/post.html", &frontmatter.FrontMatter{
+			Params: map[string]any{"aliases": []any{"/old/post.html", "older"}},
+		}),
+		newTestPage("about.md", "/about.html", &frontmatter.FrontMatter{
+			Params: map[string]any{"aliases": "/me/"},
+		}),
+	}
+
+	website, err := New(cfg, pages, nil, nil)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	want := []struct{ url, destinationPath, target string }{
+		{"/blog/older/", "public/blog/older/index.html", "/blog/post.html"},
+		{"/me/", "public/me/index.html", "/about.html"},
+		{"/old/post.html", "public/old/post.html", "/blog/post.html"},
+	}
+
+	if len(website.Aliases) != len(want) {
+		t.Fatalf("len(Aliases) = %d, want %d", len(website.Aliases), len(want))
+	}
+
+	for i, alias := range website.Aliases {
+		if alias.URL != want[i].url || alias.DestinationPath != want[i].destinationPath ||
+			alias.Page.URL != want[i].target {
+			t.Errorf(
+				"Aliases[%d] = {%s %s %s}, want %v",
+				i, alias.URL, alias.DestinationPath, alias.Page.URL, want[i],
+			)
+		}
+	}
+}
+
+func TestCheckAliases(t *testing.T) {
+	cfg := newLanguagesTestConfig()
+
+	tests := []struct {
+		name        string
+		enAliases   []any
+		kaAliases   []any
+		staticPaths []string
+		wantErr     error
+	}{
+		{
+			name:      "shouldAcceptAliasesOfBothLanguages",
+			enAliases: []any{"/old/"},
+			kaAliases: []any{"/old/"},
+		},
+		{
+			name:      "shouldRejectAliasWithURLOfPageOfOtherLanguage",
+			enAliases: []any{"/ka/"},
+			wantErr:   ErrAliasCollision,
+		},
+		{
+			name:      "shouldRejectAliasOfOtherLanguage",
+			enAliases: []any{"/ka/old/"},
+			kaAliases: []any{"/old/"},
+			wantErr:   ErrAliasCollision,
+		},
+		{
+			name:      "shouldRejectAliasGeneratedIntoResource",
+			kaAliases: []any{"/blog/cover.html"},
+			wantErr:   ErrAliasCollision,
+		},
+		{
+			name:        "shouldRejectAliasGeneratedIntoStaticFile",
+			enAliases:   []any{"/old/"},
+			staticPaths: []string{"public/old/index.html"},
+			wantErr:     ErrAliasCollision,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			enPost := newTestPage("blog/post.md", "/blog/post.html", &frontmatter.FrontMatter{
+				Params: map[string]any{"aliases": tt.enAliases},
+			})
+			kaHome := newTestPage("index.md", "/ka/", &frontmatter.FrontMatter{
+				Params: map[string]any{"aliases": tt.kaAliases},
+			})
+
+			enSite, err := New(cfg.ForLanguage("en"), []*Page{enPost}, nil, nil)
+			if err != nil {
+				t.Fatalf("New() error = %v", err)
+			}
+
+			kaSite, err := New(cfg.ForLanguage("ka"), []*Page{kaHome}, nil, nil)
+			if err != nil {
+				t.Fatalf("New() error = %v", err)
+			}
+
+			enSite.Resources = []*Resource{{
+				URL:             "/ka/blog/cover.html",
+				SourcePath:      "content/ka/blog/cover.html",
+				DestinationPath: "public/ka/blog/cover.html",
+			}}
+
+			err = CheckAliases([]*Site{enSite, kaSite}, tt.staticPaths)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("CheckAliases() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,41 @@
+package site
+
+import "errors"
+
+const (
+	PAGE_KIND_PAGE     = "page"
+	PAGE_KIND_SECTION  = "section"
+	PAGE_KIND_TAXONOMY = "taxonomy"
+	PAGE_KIND_TERM     = "term"
+
+	INDEX_FILE         = "index.md"
+	SECTION_INDEX_FILE = "_index.md"
+
+	// SUMMARY_DIVIDER on a line of its own ends the summary of the page.
+	SUMMARY_DIVIDER = "<!--more-->"
+
+	RSS_FILE       = "index.xml"
+	ATOM_FILE      = "atom.xml"
+	JSON_FEED_FILE = "feed.json"
+
+	METADATA_TYPE_ARTICLE = "article"
+	METADATA_TYPE_WEBSITE = "website"
+
+	TWITTER_CARD_SUMMARY             = "summary"
+	TWITTER_CARD_SUMMARY_LARGE_IMAGE = "summary_large_image"
+
+	SCHEMA_CONTEXT = "https://schema.org"
+
+	SORT_BY_WEIGHT = "weight"
+	SORT_BY_DATE   = "date"
+	SORT_BY_TITLE  = "title"
+)
+
+var (
+	ErrDuplicateURL   = errors.New("pages have the same URL")
+	ErrInvalidSortBy  = errors.New("invalid sortBy")
+	ErrInvalidSlug    = errors.New("invalid slug")
+	ErrInvalidAlias   = errors.New("invalid alias")
+	ErrAliasCollision = errors.New("alias collides with another URL")
+	ErrInvalidMenu    = errors.New("invalid menu")
+)
@@ -0,0 +1,99 @@
+package site
+
+import (
+	"slices"
+	"strings"
+
+	"static-site-generator/pkg/config"
+)
+
+// Feed lists the newest pages of a list page for feed readers. It is published as RSS and Atom
+// files next to its page, e.g. /blog/index.xml and /blog/atom.xml, and feeds of the site and of
+// sections also as a JSON Feed file, e.g. /blog/feed.json.
+type Feed struct {
+	Title string
+	// Page is the list page of a section or a term the feed belongs to, or the home page for the
+	// feed of the whole site.
+	Page *Page
+	// Pages are sorted by date (newest first) and title, there are at most cfg.FeedLimit of them.
+	Pages               []*Page
+	RSSURL              string
+	AtomURL             string
+	RSSDestinationPath  string
+	AtomDestinationPath string
+	// JSONFeedURL and JSONFeedDestinationPath are empty for feeds of terms.
+	JSONFeedURL             string
+	JSONFeedDestinationPath string
+}
+
+// addFeeds adds feeds of the site (listing all pages except for the home page), of list pages of
+// sections and of list pages of terms. Sections whose directory has index.md instead of a list
+// page have no feed. Sites without baseURL get no feeds, since feeds need absolute URLs.
+func (site *Site) addFeeds(cfg *config.Config) {
+	if cfg.BaseURL == "" {
+		return
+	}
+
+	homePage := site.Home.Page
+	if homePage == nil {
+		index := slices.IndexFunc(site.Pages, (*Page).IsHome)
+		if index == -1 {
+			return
+		}
+
+		homePage = site.Pages[index]
+	}
+
+	site.Feed = newFeed(
+		cfg, homePage, slices.DeleteFunc(slices.Clone(site.Pages), (*Page).IsHome), true,
+	)
+	if cfg.Title != "" {
+		site.Feed.Title = cfg.Title
+	}
+
+	site.Feeds = append(site.Feeds, site.Feed)
+
+	for _, section := range site.sections {
+		if section.Page != nil && !section.IsHome() {
+			site.Feeds = append(site.Feeds, newFeed(cfg, section.Page, section.Pages, true))
+		}
+	}
+
+	for _, name := range cfg.Taxonomies {
+		for _, term := range site.Taxonomies[name].Terms {
+			site.Feeds = append(site.Feeds, newFeed(cfg, term.Page, term.Pages, false))
+		}
+	}
+
+	slices.SortFunc(site.Feeds, func(a, b *Feed) int { return strings.Compare(a.RSSURL, b.RSSURL) })
+}
+
+// newFeed returns the feed of page listing the newest of pages, with a JSON Feed file if
+// hasJSONFeed is set, and sets it as the page's feed.
+func newFeed(cfg *config.Config, page *Page, pages []*Page, hasJSONFeed bool) *Feed {
+	pages = slices.Clone(pages)
+	sortPages(pages, pageComparisons[SORT_BY_DATE])
+
+	if cfg.FeedLimit > 0 && len(pages) > cfg.FeedLimit {
+		pages = pages[:cfg.FeedLimit]
+	}
+
+	dirURL := pageDirURL(page)
+
+	page.Feed = &Feed{
+		Title:               page.Title,
+		Page:                page,
+		Pages:               pages,
+		RSSURL:              dirURL + RSS_FILE,
+		AtomURL:             dirURL + ATOM_FILE,
+		RSSDestinationPath:  urlToDestinationPath(cfg, dirURL+RSS_FILE),
+		AtomDestinationPath: urlToDestinationPath(cfg, dirURL+ATOM_FILE),
+	}
+
+	if hasJSONFeed {
+		page.Feed.JSONFeedURL = dirURL + JSON_FEED_FILE
+		page.Feed.JSONFeedDestinationPath = urlToDestinationPath(cfg, page.Feed.JSONFeedURL)
+	}
+
+	return page.Feed
+}
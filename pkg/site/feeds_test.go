@@ -0,0 +1,96 @@
+package site
+
+import (
+	"testing"
+	"time"
+
+	"static-site-generator/pkg/config"
+	"static-site-generator/pkg/frontmatter"
+)
+
+func TestNew_Feeds(t *testing.T) {
+	day := time.Date(2024, 10, 1, 0, 0, 0, 0, time.UTC)
+
+	home := newTestPage("index.md", "/", &frontmatter.FrontMatter{Title: "Home"})
+	older := newTaxonomyTestPage("older", day, map[string]any{"tags": "Go"})
+	newer := newTaxonomyTestPage("newer", day.AddDate(0, 0, 1), map[string]any{"tags": "Go"})
+	newest := newTaxonomyTestPage("newest", day.AddDate(0, 0, 2), map[string]any{})
+	newest.Weight = 1
+
+	cfg := &config.Config{
+		BaseURL:        "https://example.com",
+		ContentDir:     "content",
+		DestinationDir: "public",
+		Title:          "Site",
+		Taxonomies:     []string{"tags"},
+		FeedLimit:      2,
+	}
+
+	site, err := New(cfg, []*Page{home, older, newer, newest}, nil, nil)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	blog := site.GetSection("blog").Page
+	goTerm := site.Taxonomies["tags"].Terms[0].Page
+
+	tests := []struct {
+		name      string
+		feed      *Feed
+		wantTitle string
+		wantURL   string
+		wantPath  string
+		wantJSON  string
+		wantPages []string
+	}{
+		{
+			"shouldListNewestPagesOfSite", site.Feed, "Site", "/index.xml", "public/atom.xml",
+			"/feed.json", []string{"newest", "newer"},
+		},
+		{
+			"shouldListNewestPagesOfSection", blog.Feed, "Blog", "/blog/index.xml",
+			"public/blog/atom.xml", "/blog/feed.json", []string{"newest", "newer"},
+		},
+		{
+			"shouldListPagesOfTermWithoutJSONFeed", goTerm.Feed, "Go", "/tags/go/index.xml",
+			"public/tags/go/atom.xml", "", []string{"newer", "older"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.feed == nil {
+				t.Fatalf("Feed = nil, want feed %s", tt.wantURL)
+			}
+
+			if tt.feed.Title != tt.wantTitle || tt.feed.RSSURL != tt.wantURL ||
+				tt.feed.AtomDestinationPath != tt.wantPath || tt.feed.JSONFeedURL != tt.wantJSON {
+				t.Errorf(
+					"Feed = (%s, %s, %s, %s), want (%s, %s, %s, %s)", tt.feed.Title,
+					tt.feed.RSSURL, tt.feed.AtomDestinationPath, tt.feed.JSONFeedURL,
+					tt.wantTitle, tt.wantURL, tt.wantPath, tt.wantJSON,
+				)
+			}
+
+			assertPageTitles(t, "Feed.Pages", tt.feed.Pages, tt.wantPages...)
+		})
+	}
+
+	if home.Feed != site.Feed || len(site.Feeds) != 3 {
+		t.Errorf("home.Feed, len(Feeds) = %p, %d, want site feed, 3", home.Feed, len(site.Feeds))
+	}
+}
+
+func TestNew_FeedsWithoutBaseURL(t *testing.T) {
+	home := newTestPage("index.md", "/", &frontmatter.FrontMatter{Title: "Home"})
+	post := newTaxonomyTestPage("post", time.Date(2024, 10, 1, 0, 0, 0, 0, time.UTC), nil)
+
+	site, err := New(&config.Config{DestinationDir: "public"}, []*Page{home, post}, nil, nil)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	if site.Feed != nil || len(site.Feeds) != 0 || home.Feed != nil {
+		t.Errorf("Feed, len(Feeds) = %v, %d, want no feeds", site.Feed, len(site.Feeds))
+	}
+}
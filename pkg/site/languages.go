@@ -0,0 +1,196 @@
+package site
+
+import (
+	"fmt"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"static-site-generator/pkg/config"
+)
+
+// Language is a language of the site. Single language sites have one language, LanguageCode of
+// their config.
+type Language struct {
+	Code string
+	// Name is shown by language switchers, e.g. "ქართული", it is Code if config sets no name.
+	Name   string
+	Title  string
+	Weight int
+	// URL is the URL of the language's home page, e.g. /ka/, or / for the default language.
+	URL       string
+	IsDefault bool
+}
+
+// Translation returns the page's translation into language with code, or nil if it has none.
+func (page *Page) Translation(code string) *Page {
+	for _, translation := range page.Translations {
+		if translation.Language.Code == code {
+			return translation
+		}
+	}
+
+	return nil
+}
+
+// LanguageOf returns code of the language of the content file at sourcePath: the language of the
+// content directory it is in, or the language of its name's suffix, e.g. "ka" for post.ka.md, or
+// else the default language.
+func LanguageOf(cfg *config.Config, sourcePath string) string {
+	for code, language := range cfg.Languages {
+		if language.ContentDir != "" && config.IsInsideDir(sourcePath, language.ContentDir) {
+			return code
+		}
+	}
+
+	if code := languageSuffix(cfg, filepath.Base(sourcePath)); code != "" {
+		return code
+	}
+
+	return cfg.DefaultLanguage
+}
+
+// SourceFileName returns the name of the Markdown file at sourcePath without its language suffix,
+// e.g. index.md for content/majesty/index.ka.md.
+func SourceFileName(cfg *config.Config, sourcePath string) string {
+	name := filepath.Base(sourcePath)
+
+	code := languageSuffix(cfg, name)
+	if code == "" {
+		return name
+	}
+
+	return strings.TrimSuffix(name, "."+code+".md") + ".md"
+}
+
+// LinkTranslations sets translations of pages of sites, which are sites of all languages of the
+// config they were built with. Paginated list pages are linked to the pages with the same number
+// of their translations. It fails if pages of different languages have the same URL, e.g. if a
+// page of the default language is in a directory named after another language.
+func LinkTranslations(sites []*Site) error {
+	pagesByKey := map[string][]*Page{}
+	pagesByURL := map[string]*Page{}
+
+	for _, site := range sites {
+		for _, page := range slices.Concat(site.Pages, site.ListPages) {
+			if other, found := pagesByURL[page.URL]; found {
+				return duplicateURLError(other, page)
+			}
+
+			pagesByURL[page.URL] = page
+
+			key := page.translationKey()
+			pagesByKey[key] = append(pagesByKey[key], page)
+		}
+	}
+
+	for _, pages := range pagesByKey {
+		for _, page := range pages {
+			page.Translations = []*Page{}
+
+			for _, translation := range pages {
+				if translation != page {
+					page.Translations = append(page.Translations, translation)
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// newLanguages returns languages of cfg sorted like cfg.LanguageCodes.
+func newLanguages(cfg *config.Config) []*Language {
+	languages := []*Language{}
+
+	for _, code := range cfg.LanguageCodes() {
+		language := &Language{
+			Code:      code,
+			Name:      code,
+			Title:     cfg.Title,
+			URL:       "/",
+			IsDefault: code == cfg.DefaultLanguage || !cfg.IsMultilingual(),
+		}
+
+		if languageConfig, found := cfg.Languages[code]; found {
+			language.Weight = languageConfig.Weight
+
+			if languageConfig.Name != "" {
+				language.Name = languageConfig.Name
+			}
+
+			if languageConfig.Title != "" {
+				language.Title = languageConfig.Title
+			}
+		}
+
+		if !language.IsDefault {
+			language.URL = "/" + code + "/"
+		}
+
+		languages = append(languages, language)
+	}
+
+	return languages
+}
+
+// translationKey identifies the page among pages of all languages: translationKey front matter,
+// or the source path relative to content directory, or the path of _index.md of generated list
+// pages of sections, or the kind and the directory of list pages of taxonomies and terms. Pages of
+// paginated list pages after the first one get their number appended.
+func (page *Page) translationKey() string {
+	key, _ := page.String("translationKey")
+
+	switch {
+	case key != "":
+	case page.relativePath != "":
+		key = page.relativePath
+	case page.Kind == PAGE_KIND_SECTION:
+		key = path.Join(page.dir, SECTION_INDEX_FILE)
+	default:
+		key = page.Kind + ":" + page.dir
+	}
+
+	if page.Paginator != nil && page.Paginator.PageNumber > 1 {
+		key += fmt.Sprintf("#%d", page.Paginator.PageNumber)
+	}
+
+	return key
+}
+
+// languageSuffix returns code of the language whose suffix the name of a Markdown file has, e.g.
+// "ka" for post.ka.md, or an empty string if it has none.
+func languageSuffix(cfg *config.Config, name string) string {
+	if !strings.HasSuffix(name, ".md") {
+		return ""
+	}
+
+	code := strings.TrimPrefix(path.Ext(strings.TrimSuffix(name, ".md")), ".")
+	if _, found := cfg.Languages[code]; !found {
+		return ""
+	}
+
+	return code
+}
+
+// contentRelativePath returns slash separated path of the content file at sourcePath relative to
+// the content directory of cfg's language if the file is inside it, or else relative to content
+// directory.
+func contentRelativePath(cfg *config.Config, sourcePath string) (string, error) {
+	contentDir := cfg.ContentDir
+
+	language := cfg.Languages[cfg.LanguageCode]
+	if language.ContentDir != "" && config.IsInsideDir(sourcePath, language.ContentDir) {
+		contentDir = language.ContentDir
+	}
+
+	relativePath, err := filepath.Rel(contentDir, sourcePath)
+	if err != nil {
+		return "", fmt.Errorf(
+			"source (%s) is outside of content directory (%s): %v", sourcePath, contentDir, err,
+		)
+	}
+
+	return filepath.ToSlash(relativePath), nil
+}
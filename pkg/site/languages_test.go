@@ -0,0 +1,139 @@
+package site
+
+import (
+	"errors"
+	"testing"
+
+	"static-site-generator/pkg/config"
+	"static-site-generator/pkg/frontmatter"
+)
+
+func newLanguagesTestConfig() *config.Config {
+	return &config.Config{
+		ContentDir:      "content",
+		DestinationDir:  "public",
+		LanguageCode:    "en",
+		DefaultLanguage: "en",
+		Languages: map[string]config.Language{
+			"en": {Name: "English"},
+			"ka": {Name: "ქართული", ContentDir: "content-ka"},
+			"de": {Name: "Deutsch"},
+		},
+	}
+}
+
+func TestLanguageOf(t *testing.T) {
+	cfg := newLanguagesTestConfig()
+
+	tests := []struct {
+		sourcePath   string
+		wantLanguage string
+		wantFileName string
+	}{
+		{"content/blog/post.md", "en", "post.md"},
+		{"content/blog/post.de.md", "de", "post.md"},
+		{"content/majesty/index.ka.md", "ka", "index.md"},
+		{"content/blog/_index.de.md", "de", "_index.md"},
+		{"content/blog/release.v2.md", "en", "release.v2.md"},
+		{"content-ka/blog/post.md", "ka", "post.md"},
+		{"content/blog/diagram.de.png", "en", "diagram.de.png"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.sourcePath, func(t *testing.T) {
+			language, fileName := LanguageOf(cfg, tt.sourcePath), SourceFileName(cfg, tt.sourcePath)
+			if language != tt.wantLanguage || fileName != tt.wantFileName {
+				t.Errorf(
+					"LanguageOf(), SourceFileName() = %s, %s, want %s, %s",
+					language, fileName, tt.wantLanguage, tt.wantFileName,
+				)
+			}
+		})
+	}
+}
+
+func TestLinkTranslations(t *testing.T) {
+	cfg := newLanguagesTestConfig()
+
+	enHome := newTestPage("index.md", "/", &frontmatter.FrontMatter{Title: "Home"})
+	enPost := newTestPage("blog/post.md", "/blog/post.html", &frontmatter.FrontMatter{Title: "Post"})
+	enOnly := newTestPage("blog/only.md", "/blog/only.html", &frontmatter.FrontMatter{Title: "Only"})
+	kaHome := newTestPage("index.md", "/ka/", &frontmatter.FrontMatter{Title: "მთავარი"})
+	kaPost := newTestPage(
+		"blog/post.md", "/ka/blog/post.html", &frontmatter.FrontMatter{Title: "პოსტი"},
+	)
+
+	enSite, err := New(cfg.ForLanguage("en"), []*Page{enHome, enPost, enOnly}, nil, nil)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	kaSite, err := New(cfg.ForLanguage("ka"), []*Page{kaHome, kaPost}, nil, nil)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	if err := LinkTranslations([]*Site{enSite, kaSite}); err != nil {
+		t.Fatalf("LinkTranslations() error = %v", err)
+	}
+
+	if kaSite.Language.Code != "ka" || kaSite.Language.URL != "/ka/" || len(kaSite.Languages) != 3 {
+		t.Errorf(
+			"Language, Languages = %+v, %d language(s), want ka at /ka/ and 3 languages",
+			kaSite.Language, len(kaSite.Languages),
+		)
+	}
+
+	if !kaHome.IsHome() || kaHome.Language != kaSite.Language {
+		t.Errorf(
+			"kaHome.IsHome(), Language = %t, %+v, want true, ka", kaHome.IsHome(), kaHome.Language,
+		)
+	}
+
+	enBlog, kaBlog := enSite.GetSection("blog").Page, kaSite.GetSection("blog").Page
+	if kaBlog.URL != "/ka/blog/" {
+		t.Errorf("kaBlog.URL = %s, want /ka/blog/", kaBlog.URL)
+	}
+
+	tests := []struct {
+		name string
+		page *Page
+		code string
+		want *Page
+	}{
+		{"shouldLinkHomePages", enHome, "ka", kaHome},
+		{"shouldLinkPagesBothWays", kaPost, "en", enPost},
+		{"shouldLinkGeneratedListPages", enBlog, "ka", kaBlog},
+		{"shouldReturnNilForMissingTranslation", enOnly, "ka", nil},
+		{"shouldReturnNilForLanguageWithoutPages", enPost, "de", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.page.Translation(tt.code); got != tt.want {
+				t.Errorf("Translation(%s) = %v, want %v", tt.code, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLinkTranslations_DuplicateURL(t *testing.T) {
+	cfg := newLanguagesTestConfig()
+
+	enPost := newTestPage("ka/post.md", "/ka/post.html", &frontmatter.FrontMatter{Title: "Post"})
+	kaPost := newTestPage("post.md", "/ka/post.html", &frontmatter.FrontMatter{Title: "პოსტი"})
+
+	enSite, err := New(cfg.ForLanguage("en"), []*Page{enPost}, nil, nil)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	kaSite, err := New(cfg.ForLanguage("ka"), []*Page{kaPost}, nil, nil)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	if err := LinkTranslations([]*Site{enSite, kaSite}); !errors.Is(err, ErrDuplicateURL) {
+		t.Errorf("LinkTranslations() error = %v, want %v", err, ErrDuplicateURL)
+	}
+}
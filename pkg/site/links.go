@@ -0,0 +1,125 @@
+package site
+
+import (
+	"fmt"
+	"html/template"
+	"path"
+	"strings"
+
+	hn "static-site-generator/pkg/htmlnodes"
+)
+
+// resolveLinks rewrites links of page's content to Markdown files of content directory, e.g.
+// [Majesty](../majesty/index.md), into URLs of their pages, so that links follow the URL scheme
+// of the site. Links and images pointing to other files of content directory, e.g. ![](cover.png),
+// are rewritten into URLs of their resources. Paths starting with / are relative to content
+// directory, other paths are relative to page's directory. pagesByPath, unpublishedByPath and
+// resourcesByPath are keyed by source paths relative to content directory. Links to unpublished
+// pages (e.g. drafts) are rendered as their text with a warning, so that they aren't dead links,
+// links to missing Markdown files are errors. Other links that match no resource are kept, e.g.
+// links to static files.
+func resolveLinks(
+	page *Page,
+	pagesByPath, unpublishedByPath map[string]*Page,
+	resourcesByPath map[string]*Resource,
+) error {
+	if page.Tree == nil {
+		return nil
+	}
+
+	isChanged := false
+
+	err := walkLeafNodes(page.Tree, func(node *hn.LeafNode) error {
+		attribute := linkAttributes[node.Tag]
+		link := node.Props[attribute]
+		if attribute == "" || link == "" || strings.Contains(link, ":") ||
+			strings.HasPrefix(link, "//") || strings.HasPrefix(link, "#") {
+			return nil
+		}
+
+		linkPath, fragment, _ := strings.Cut(link, "#")
+
+		targetPath := path.Join(page.dir, linkPath)
+		if strings.HasPrefix(linkPath, "/") {
+			targetPath = strings.TrimPrefix(path.Clean(linkPath), "/")
+		}
+
+		targetURL := ""
+
+		if strings.HasSuffix(linkPath, ".md") {
+			target, found := pagesByPath[targetPath]
+			if _, isUnpublished := unpublishedByPath[targetPath]; !found && isUnpublished {
+				fmt.Printf(
+					"Rendered link of page (%s) to (%s) as text, its page isn't published.\n",
+					page.SourcePath, link,
+				)
+
+				if node.Tag == "a" {
+					node.Tag, node.Props = "", nil
+					isChanged = true
+				}
+
+				return nil
+			}
+
+			if !found {
+				return fmt.Errorf(
+					"page (%s) links to (%s), which doesn't exist", page.SourcePath, link,
+				)
+			}
+
+			targetURL = target.URL
+		} else {
+			resource, found := resourcesByPath[targetPath]
+			if !found {
+				return nil
+			}
+
+			targetURL = resource.URL
+		}
+
+		node.Props[attribute] = targetURL
+		if fragment != "" {
+			node.Props[attribute] += "#" + fragment
+		}
+
+		isChanged = true
+
+		return nil
+	})
+	if err != nil || !isChanged {
+		return err
+	}
+
+	content, err := page.Tree.ToHTML()
+	if err != nil {
+		return fmt.Errorf("page (%s): %v", page.SourcePath, err)
+	}
+
+	page.Content = template.HTML(content)
+
+	if err := page.summarize(); err != nil {
+		return fmt.Errorf("page (%s): %v", page.SourcePath, err)
+	}
+
+	return nil
+}
+
+// linkAttributes are attributes of links of content, keyed by tags.
+var linkAttributes = map[string]string{"a": "href", "img": "src"}
+
+// walkLeafNodes calls visit for every leaf node of the tree under node.
+func walkLeafNodes(node hn.HTMLStringer, visit func(node *hn.LeafNode) error) error {
+	switch node := node.(type) {
+	case *hn.LeafNode:
+		return visit(node)
+	case *hn.ParentNode:
+		for _, child := range node.Children {
+			if err := walkLeafNodes(child, visit); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,91 @@
+package site
+
+import (
+	"html/template"
+	"testing"
+
+	"static-site-generator/pkg/adapters"
+)
+
+func newLinkTestPage(t *testing.T, relativeSourcePath, url, markdown string) *Page {
+	t.Helper()
+
+	tree, err := adapters.MarkdownToHTMLNode(markdown)
+	if err != nil {
+		t.Fatalf("MarkdownToHTMLNode() error = %v", err)
+	}
+
+	return &Page{
+		Tree:         tree,
+		URL:          url,
+		SourcePath:   "content/" + relativeSourcePath,
+		relativePath: relativeSourcePath,
+		dir:          dirOf(relativeSourcePath),
+	}
+}
+
+func TestResolveLinks(t *testing.T) {
+	majesty := newLinkTestPage(t, "majesty/index.md", "/majesty/", "Majesty.")
+	post := newLinkTestPage(t, "blog/post.md", "/blog/post/", "Post.")
+	draft := newLinkTestPage(t, "blog/draft.md", "/blog/draft/", "Draft.")
+	pagesByPath := map[string]*Page{majesty.relativePath: majesty, post.relativePath: post}
+	unpublishedByPath := map[string]*Page{draft.relativePath: draft}
+	resourcesByPath := map[string]*Resource{
+		"blog/diagram.png":  {Name: "diagram.png", URL: "/blog/diagram.png"},
+		"majesty/notes.pdf": {Name: "notes.pdf", URL: "/lotr/notes.pdf"},
+	}
+
+	tests := []struct {
+		name        string
+		markdown    string
+		wantContent template.HTML
+		wantErr     bool
+	}{
+		{
+			name:        "shouldResolveRelativeLinkWithFragment",
+			markdown:    "See [majesty](../majesty/index.md#themes).",
+			wantContent: `<div><p>See <a href="/majesty/#themes">majesty</a>.</p></div>`,
+		},
+		{
+			name:     "shouldResolveLinkRelativeToContentDirectory",
+			markdown: "See [post](/blog/post.md) and [same](post.md).",
+			wantContent: `<div><p>See <a href="/blog/post/">post</a> and ` +
+				`<a href="/blog/post/">same</a>.</p></div>`,
+		},
+		{
+			name:        "shouldKeepOtherLinks",
+			markdown:    "See [site](https://example.com/readme.md) and [image](/images/a.png).",
+			wantContent: "",
+		},
+		{
+			name:     "shouldResolveLinksToResources",
+			markdown: "![diagram](diagram.png) and [notes](../majesty/notes.pdf#page=2).",
+			wantContent: `<div><p><img alt="diagram" src="/blog/diagram.png"></img> and ` +
+				`<a href="/lotr/notes.pdf#page=2">notes</a>.</p></div>`,
+		},
+		{
+			name:        "shouldRenderLinkToUnpublishedPageAsText",
+			markdown:    "See [draft](draft.md#intro) and [post](post.md).",
+			wantContent: `<div><p>See draft and <a href="/blog/post/">post</a>.</p></div>`,
+		},
+		{
+			name:     "shouldFailForLinkToMissingPage",
+			markdown: "See [missing](missing.md).",
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			page := newLinkTestPage(t, "blog/links.md", "/blog/links/", tt.markdown)
+
+			err := resolveLinks(page, pagesByPath, unpublishedByPath, resourcesByPath)
+			if page.Content != tt.wantContent || (err != nil) != tt.wantErr {
+				t.Errorf(
+					"resolveLinks() content = %q, error = %v, want %q, error: %v",
+					page.Content, err, tt.wantContent, tt.wantErr,
+				)
+			}
+		})
+	}
+}
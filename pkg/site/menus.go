@@ -0,0 +1,265 @@
+package site
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"static-site-generator/pkg/config"
+	"static-site-generator/pkg/frontmatter"
+)
+
+// Menu is a named list of links, e.g. the main navigation. It holds top level entries, sorted by
+// weight (entries without weight last) and name.
+type Menu []*MenuEntry
+
+// MenuEntry is a link of a menu, added by site config or by menu front matter of a page.
+type MenuEntry struct {
+	Name string
+	URL  string
+	// Identifier is what parent of other entries of the same menu refers to, Name by default.
+	Identifier string
+	Weight     int
+	// Page is the page that added the entry, nil for entries of site config.
+	Page   *Page
+	Parent *MenuEntry
+	// Children are sorted like top level entries of the menu.
+	Children []*MenuEntry
+
+	parentIdentifier string
+}
+
+// HasChildren reports whether the entry has nested entries.
+func (entry *MenuEntry) HasChildren() bool {
+	return len(entry.Children) != 0
+}
+
+// IsActive reports whether the entry links to page (to any page of it, if page is paginated).
+func (entry *MenuEntry) IsActive(page *Page) bool {
+	if page == nil {
+		return false
+	}
+
+	if page.Paginator != nil && entry.URL == page.Paginator.FirstURL {
+		return true
+	}
+
+	return entry.URL == page.URL
+}
+
+// IsAncestorActive reports whether one of the entry's descendants links to page, or the entry
+// links to a section (other than the home section) page is in.
+func (entry *MenuEntry) IsAncestorActive(page *Page) bool {
+	if page == nil {
+		return false
+	}
+
+	for _, child := range entry.Children {
+		if child.IsActive(page) || child.IsAncestorActive(page) {
+			return true
+		}
+	}
+
+	if entry.IsActive(page) || len(page.Breadcrumbs) == 0 {
+		return false
+	}
+
+	// The first breadcrumb is the home section, which every page is in.
+	return slices.ContainsFunc(page.Breadcrumbs[1:], func(breadcrumb *Breadcrumb) bool {
+		return breadcrumb.URL == entry.URL
+	})
+}
+
+// addMenus builds site.Menus from entries of cfg.Menus and from menu front matter of pages,
+// which is a menu name, a list of them or a map of menu names to entry settings (name, weight,
+// parent and identifier). Entries of pages are named after their titles and weighted by their
+// weight front matter by default. It fails if front matter is invalid, if an identifier repeats
+// within a menu or if a parent is missing.
+func (site *Site) addMenus(cfg *config.Config, pages []*Page) error {
+	errs := []error{}
+	entriesByMenu := map[string][]*MenuEntry{}
+
+	for menu, configEntries := range cfg.Menus {
+		for _, configEntry := range configEntries {
+			entriesByMenu[menu] = append(entriesByMenu[menu], &MenuEntry{
+				Name:             configEntry.Name,
+				URL:              configEntry.URL,
+				Identifier:       configEntry.Identifier,
+				Weight:           configEntry.Weight,
+				parentIdentifier: configEntry.Parent,
+			})
+		}
+	}
+
+	for _, page := range pages {
+		pageEntries, err := pageMenuEntries(page)
+		if err != nil {
+			errs = append(errs, fmt.Errorf(
+				"%w: page (%s): %v", ErrInvalidMenu, page.SourcePath, err,
+			))
+			continue
+		}
+
+		for menu, entry := range pageEntries {
+			entriesByMenu[menu] = append(entriesByMenu[menu], entry)
+		}
+	}
+
+	for _, menu := range slices.Sorted(maps.Keys(entriesByMenu)) {
+		entries, err := buildMenu(entriesByMenu[menu])
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%w (%s): %v", ErrInvalidMenu, menu, err))
+			continue
+		}
+
+		site.Menus[menu] = entries
+	}
+
+	return errors.Join(errs...)
+}
+
+// pageMenuEntries returns entries page adds with its menu front matter, keyed by menu names.
+func pageMenuEntries(page *Page) (map[string]*MenuEntry, error) {
+	newEntry := func() *MenuEntry {
+		return &MenuEntry{Name: page.Title, URL: page.URL, Weight: page.Weight, Page: page}
+	}
+
+	entries := map[string]*MenuEntry{}
+
+	settingsByMenu, ok := page.Params["menu"].(map[string]any)
+	if !ok {
+		menus, err := page.Strings("menu")
+		if err != nil {
+			return nil, err
+		}
+
+		for _, menu := range menus {
+			entries[menu] = newEntry()
+		}
+
+		return entries, nil
+	}
+
+	for menu, value := range settingsByMenu {
+		entry := newEntry()
+		entries[menu] = entry
+
+		params, ok := value.(map[string]any)
+		if !ok {
+			if value != nil {
+				return nil, fmt.Errorf("menu.%s must be a map, got %v (%T)", menu, value, value)
+			}
+
+			continue
+		}
+
+		settings, err := frontmatter.New(params)
+		if err != nil {
+			return nil, fmt.Errorf("menu.%s: %v", menu, err)
+		}
+
+		name, err := settings.String("name")
+		if err != nil {
+			return nil, fmt.Errorf("menu.%s: %v", menu, err)
+		}
+
+		if name != "" {
+			entry.Name = name
+		}
+
+		if _, found := params["weight"]; found {
+			entry.Weight = settings.Weight
+		}
+
+		if entry.Identifier, err = settings.String("identifier"); err != nil {
+			return nil, fmt.Errorf("menu.%s: %v", menu, err)
+		}
+
+		if entry.parentIdentifier, err = settings.String("parent"); err != nil {
+			return nil, fmt.Errorf("menu.%s: %v", menu, err)
+		}
+	}
+
+	return entries, nil
+}
+
+// buildMenu nests entries under their parents and sorts them.
+func buildMenu(entries []*MenuEntry) (Menu, error) {
+	entriesByIdentifier := map[string]*MenuEntry{}
+
+	for _, entry := range entries {
+		if entry.Identifier == "" {
+			entry.Identifier = entry.Name
+		}
+
+		if entry.Identifier == "" {
+			return nil, fmt.Errorf("entry with URL (%s) has no name", entry.URL)
+		}
+
+		if _, found := entriesByIdentifier[entry.Identifier]; found {
+			return nil, fmt.Errorf(
+				"identifier (%s) is used by more than one entry", entry.Identifier,
+			)
+		}
+
+		entriesByIdentifier[entry.Identifier] = entry
+	}
+
+	menu := Menu{}
+
+	for _, entry := range entries {
+		if entry.parentIdentifier == "" {
+			menu = append(menu, entry)
+			continue
+		}
+
+		parent, found := entriesByIdentifier[entry.parentIdentifier]
+		if !found {
+			return nil, fmt.Errorf(
+				"parent (%s) of entry (%s) doesn't exist", entry.parentIdentifier, entry.Identifier,
+			)
+		}
+
+		// Any chain of parents longer than the number of entries must loop.
+		ancestor := parent
+		for range entries {
+			if ancestor == nil {
+				break
+			}
+
+			if ancestor == entry {
+				return nil, fmt.Errorf("entry (%s) is its own ancestor", entry.Identifier)
+			}
+
+			ancestor = entriesByIdentifier[ancestor.parentIdentifier]
+		}
+
+		entry.Parent = parent
+		parent.Children = append(parent.Children, entry)
+	}
+
+	sortMenuEntries(menu)
+
+	return menu, nil
+}
+
+func sortMenuEntries(entries []*MenuEntry) {
+	slices.SortStableFunc(entries, func(a, b *MenuEntry) int {
+		switch {
+		case a.Weight == b.Weight:
+			return strings.Compare(a.Name, b.Name)
+		case a.Weight == 0:
+			return 1
+		case b.Weight == 0:
+			return -1
+		default:
+			return a.Weight - b.Weight
+		}
+	})
+
+	for _, entry := range entries {
+		sortMenuEntries(entry.Children)
+	}
+}
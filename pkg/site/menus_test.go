@@ -0,0 +1,131 @@
+package site
+
+import (
+	"errors"
+	"testing"
+
+	"static-site-generator/pkg/config"
+	"static-site-generator/pkg/frontmatter"
+)
+
+func assertMenuEntryNames(t *testing.T, name string, entries []*MenuEntry, want ...string) {
+	t.Helper()
+
+	got := []string{}
+	for _, entry := range entries {
+		got = append(got, entry.Name)
+	}
+
+	if len(got) != len(want) {
+		t.Fatalf("%s = %v, want %v", name, got, want)
+	}
+
+	for i := range got {
+		if got[i] != want[i] {
+			t.Fatalf("%s = %v, want %v", name, got, want)
+		}
+	}
+}
+
+func TestNew_Menus(t *testing.T) {
+	cfg := &config.Config{
+		Menus: map[string][]config.MenuEntry{
+			"main": {
+				{Name: "Home", URL: "/", Weight: 1},
+				{Name: "GitHub", URL: "https://github.com/"},
+			},
+			"footer": {{Name: "About", URL: "/about.html"}},
+		},
+	}
+
+	docsIndex := newTestPage("docs/_index.md", "/docs/", &frontmatter.FrontMatter{
+		Title: "Docs", Weight: 2, Params: map[string]any{"menu": "main"},
+	})
+	docsIndex.Kind = PAGE_KIND_SECTION
+	install := newTestPage("docs/install.md", "/docs/install.html", &frontmatter.FrontMatter{
+		Title: "Installation",
+		Params: map[string]any{"menu": map[string]any{
+			"main": map[string]any{"name": "Install", "parent": "Docs", "weight": 1},
+		}},
+	})
+	usage := newTestPage("docs/usage.md", "/docs/usage.html", &frontmatter.FrontMatter{
+		Title: "Usage", Weight: 2,
+		Params: map[string]any{"menu": map[string]any{"main": map[string]any{"parent": "Docs"}}},
+	})
+	faq := newTestPage("docs/faq.md", "/docs/faq.html", &frontmatter.FrontMatter{Title: "FAQ"})
+	about := newTestPage("about.md", "/about.html", &frontmatter.FrontMatter{Title: "About"})
+
+	site, err := New(cfg, []*Page{docsIndex, install, usage, faq, about}, nil, nil)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	main := site.Menus["main"]
+	assertMenuEntryNames(t, "Menus.main", main, "Home", "Docs", "GitHub")
+	assertMenuEntryNames(t, "Menus.main.Docs.Children", main[1].Children, "Install", "Usage")
+	assertMenuEntryNames(t, "Menus.footer", site.Menus["footer"], "About")
+
+	docs := main[1]
+	if docs.Page != docsIndex || !docs.HasChildren() || docs.Children[0].Parent != docs {
+		t.Errorf("Docs entry = %+v, want entry of docs/_index.md with children", docs)
+	}
+
+	activeTests := []struct {
+		name               string
+		entry              *MenuEntry
+		page               *Page
+		wantActive         bool
+		wantAncestorActive bool
+	}{
+		{"shouldActivateEntryOfPage", docs.Children[0], install, true, false},
+		{"shouldActivateAncestorOfActiveEntry", docs, install, false, true},
+		{"shouldActivateSectionOfPage", docs, faq, false, true},
+		{"shouldActivateSectionPage", docs, docsIndex, true, false},
+		{"shouldActivateConfigEntry", site.Menus["footer"][0], about, true, false},
+		{"shouldNotActivateHomeAsAncestor", main[0], install, false, false},
+		{"shouldNotActivateOtherEntry", main[2], install, false, false},
+	}
+
+	for _, tt := range activeTests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.entry.IsActive(tt.page); got != tt.wantActive {
+				t.Errorf("IsActive() = %v, want %v", got, tt.wantActive)
+			}
+
+			if got := tt.entry.IsAncestorActive(tt.page); got != tt.wantAncestorActive {
+				t.Errorf("IsAncestorActive() = %v, want %v", got, tt.wantAncestorActive)
+			}
+		})
+	}
+}
+
+func TestNew_MenuErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		entries []config.MenuEntry
+	}{
+		{"shouldFailForMissingParent", []config.MenuEntry{{Name: "A", URL: "/a/", Parent: "B"}}},
+		{
+			"shouldFailForRepeatedIdentifier",
+			[]config.MenuEntry{{Name: "A", URL: "/a/"}, {Name: "B", URL: "/b/", Identifier: "A"}},
+		},
+		{
+			"shouldFailForLoopOfParents",
+			[]config.MenuEntry{
+				{Name: "A", URL: "/a/", Parent: "B"},
+				{Name: "B", URL: "/b/", Parent: "A"},
+				{Name: "C", URL: "/c/", Parent: "A"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &config.Config{Menus: map[string][]config.MenuEntry{"main": tt.entries}}
+
+			if _, err := New(cfg, []*Page{}, nil, nil); !errors.Is(err, ErrInvalidMenu) {
+				t.Errorf("New() error = %v, want %v", err, ErrInvalidMenu)
+			}
+		})
+	}
+}
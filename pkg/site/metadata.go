@@ -0,0 +1,181 @@
+package site
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"slices"
+	"time"
+
+	"static-site-generator/pkg/config"
+)
+
+// Metadata describes the page for link previews (Open Graph and Twitter cards) and search engines
+// (JSON-LD structured data). URLs of metadata are absolute, link previews don't work with
+// relative ones, so they are empty if config has no baseURL (unless image is an absolute URL).
+type Metadata struct {
+	Title string
+	// Description is description front matter, or the page's plain text summary, or description of
+	// the config if the page has neither.
+	Description string
+	// Image is image front matter made absolute for the page, or image of the config made absolute
+	// for the site root, see AbsoluteURL. It is empty if neither is set.
+	Image string
+	// Type is Open Graph type: type front matter, or "website" for the home page and list pages
+	// and "article" for other pages.
+	Type     string
+	URL      string
+	SiteName string
+	// TwitterCard is "summary_large_image" if the page has an image, or else "summary".
+	TwitterCard string
+	// StructuredData is JSON-LD of the page: a schema.org WebSite for the home page, an Article
+	// for pages of type article and a WebPage for others. Templates render it as JSON inside
+	// <script type="application/ld+json">.
+	StructuredData map[string]any
+}
+
+// addMetadata sets metadata of all pages of the site.
+func (site *Site) addMetadata(cfg *config.Config) error {
+	errs := []error{}
+
+	for _, page := range slices.Concat(site.Pages, site.ListPages) {
+		metadata, err := newMetadata(cfg, page)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("page (%s): %w", page.SourcePath, err))
+			continue
+		}
+
+		page.Metadata = metadata
+	}
+
+	return errors.Join(errs...)
+}
+
+func newMetadata(cfg *config.Config, page *Page) (*Metadata, error) {
+	metadata := &Metadata{
+		Title:       page.Title,
+		Description: page.Description,
+		SiteName:    cfg.Title,
+		TwitterCard: TWITTER_CARD_SUMMARY,
+	}
+
+	if cfg.BaseURL != "" {
+		metadata.URL = cfg.BaseURL + page.URL
+	}
+
+	if metadata.Description == "" {
+		metadata.Description = page.PlainSummary
+	}
+
+	if metadata.Description == "" {
+		metadata.Description = cfg.Description
+	}
+
+	image, err := page.String("image")
+	if err != nil {
+		return nil, err
+	}
+
+	imagePageURL := page.URL
+	if image == "" {
+		image, imagePageURL = cfg.Image, "/"
+	}
+
+	if image != "" {
+		metadata.Image, err = AbsoluteURL(cfg.BaseURL, imagePageURL, image)
+		if err != nil {
+			return nil, fmt.Errorf("image (%s) must be a URL: %v", image, err)
+		}
+	}
+
+	if isAbsoluteURL(metadata.Image) {
+		metadata.TwitterCard = TWITTER_CARD_SUMMARY_LARGE_IMAGE
+	} else {
+		metadata.Image = ""
+	}
+
+	metadata.Type, err = page.String("type")
+	if err != nil {
+		return nil, err
+	}
+
+	if metadata.Type == "" {
+		metadata.Type = METADATA_TYPE_ARTICLE
+		if page.IsHome() || page.IsList() {
+			metadata.Type = METADATA_TYPE_WEBSITE
+		}
+	}
+
+	metadata.StructuredData, err = newStructuredData(cfg, page, metadata)
+	if err != nil {
+		return nil, err
+	}
+
+	return metadata, nil
+}
+
+// newStructuredData returns JSON-LD of page with metadata, without empty properties.
+func newStructuredData(
+	cfg *config.Config, page *Page, metadata *Metadata,
+) (map[string]any, error) {
+	data := map[string]any{
+		"@context":    SCHEMA_CONTEXT,
+		"@type":       "WebPage",
+		"url":         metadata.URL,
+		"name":        metadata.Title,
+		"description": metadata.Description,
+		"image":       metadata.Image,
+		"inLanguage":  cfg.LanguageCode,
+	}
+
+	switch {
+	case page.IsHome():
+		data["@type"] = "WebSite"
+		data["name"] = metadata.SiteName
+	case metadata.Type == METADATA_TYPE_ARTICLE:
+		author, err := page.String("author")
+		if err != nil {
+			return nil, err
+		}
+
+		if author == "" {
+			author = cfg.Author
+		}
+
+		lastMod, err := page.Time("lastmod")
+		if err != nil {
+			return nil, err
+		}
+
+		data["@type"] = "Article"
+		data["headline"] = metadata.Title
+		delete(data, "name")
+
+		if author != "" {
+			data["author"] = map[string]any{"@type": "Person", "name": author}
+		}
+
+		if !page.Date.IsZero() {
+			data["datePublished"] = page.Date.Format(time.RFC3339)
+		}
+
+		if !lastMod.IsZero() {
+			data["dateModified"] = lastMod.Format(time.RFC3339)
+		}
+	}
+
+	for key, value := range data {
+		if value == "" {
+			delete(data, key)
+		}
+	}
+
+	return data, nil
+}
+
+// isAbsoluteURL reports whether rawURL has a scheme and a host, e.g. https://example.com/a.png.
+func isAbsoluteURL(rawURL string) bool {
+	parsedURL, err := url.Parse(rawURL)
+
+	return err == nil && parsedURL.Scheme != "" && parsedURL.Host != ""
+}
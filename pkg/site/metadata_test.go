@@ -0,0 +1,207 @@
+package site
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"static-site-generator/pkg/config"
+	"static-site-generator/pkg/frontmatter"
+)
+
+func TestNew_Metadata(t *testing.T) {
+	cfg := &config.Config{
+		BaseURL:      "https://example.com",
+		Title:        "Example",
+		LanguageCode: "en",
+		Author:       "Me",
+		Description:  "Example site",
+		Image:        "images/cover.png",
+	}
+	date := time.Date(2024, 10, 1, 0, 0, 0, 0, time.UTC)
+
+	home := newTestPage("index.md", "/", &frontmatter.FrontMatter{Title: "Home"})
+	post := newTestPage("blog/post/index.md", "/blog/post/", &frontmatter.FrontMatter{
+		Title:       "Post",
+		Date:        date,
+		Description: "About the post",
+		Params:      map[string]any{"image": "map.png", "author": "Nodari"},
+	})
+	about := newTestPage("about.md", "/about.html", &frontmatter.FrontMatter{
+		Title:  "About",
+		Params: map[string]any{"type": "profile", "image": "https://cdn.example.com/me.png"},
+	})
+
+	post.Kind, about.Kind = PAGE_KIND_PAGE, PAGE_KIND_PAGE
+
+	site, err := New(cfg, []*Page{home, post, about}, nil, nil)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	tests := []struct {
+		name string
+		page *Page
+		want *Metadata
+	}{
+		{
+			name: "shouldDescribeHomeAsWebSite",
+			page: home,
+			want: &Metadata{
+				Title:       "Home",
+				Description: "Example site",
+				Image:       "https://example.com/images/cover.png",
+				Type:        METADATA_TYPE_WEBSITE,
+				URL:         "https://example.com/",
+				SiteName:    "Example",
+				TwitterCard: TWITTER_CARD_SUMMARY_LARGE_IMAGE,
+				StructuredData: map[string]any{
+					"@context":    SCHEMA_CONTEXT,
+					"@type":       "WebSite",
+					"url":         "https://example.com/",
+					"name":        "Example",
+					"description": "Example site",
+					"image":       "https://example.com/images/cover.png",
+					"inLanguage":  "en",
+				},
+			},
+		},
+		{
+			name: "shouldDescribePageAsArticleWithImageRelativeToPage",
+			page: post,
+			want: &Metadata{
+				Title:       "Post",
+				Description: "About the post",
+				Image:       "https://example.com/blog/post/map.png",
+				Type:        METADATA_TYPE_ARTICLE,
+				URL:         "https://example.com/blog/post/",
+				SiteName:    "Example",
+				TwitterCard: TWITTER_CARD_SUMMARY_LARGE_IMAGE,
+				StructuredData: map[string]any{
+					"@context":      SCHEMA_CONTEXT,
+					"@type":         "Article",
+					"url":           "https://example.com/blog/post/",
+					"headline":      "Post",
+					"description":   "About the post",
+					"image":         "https://example.com/blog/post/map.png",
+					"inLanguage":    "en",
+					"author":        map[string]any{"@type": "Person", "name": "Nodari"},
+					"datePublished": "2024-10-01T00:00:00Z",
+				},
+			},
+		},
+		{
+			name: "shouldKeepTypeFrontMatterAndAbsoluteImage",
+			page: about,
+			want: &Metadata{
+				Title:       "About",
+				Description: "Example site",
+				Image:       "https://cdn.example.com/me.png",
+				Type:        "profile",
+				URL:         "https://example.com/about.html",
+				SiteName:    "Example",
+				TwitterCard: TWITTER_CARD_SUMMARY_LARGE_IMAGE,
+				StructuredData: map[string]any{
+					"@context":    SCHEMA_CONTEXT,
+					"@type":       "WebPage",
+					"url":         "https://example.com/about.html",
+					"name":        "About",
+					"description": "Example site",
+					"image":       "https://cdn.example.com/me.png",
+					"inLanguage":  "en",
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !reflect.DeepEqual(tt.page.Metadata, tt.want) {
+				t.Errorf("Metadata = %+v, want %+v", tt.page.Metadata, tt.want)
+			}
+		})
+	}
+
+	blog := site.GetSection("blog").Page
+	if blog.Metadata == nil || blog.Metadata.Type != METADATA_TYPE_WEBSITE ||
+		blog.Metadata.StructuredData["@type"] != "WebPage" {
+		t.Errorf("blog.Metadata = %+v, want a website described as WebPage", blog.Metadata)
+	}
+}
+
+func TestNew_MetadataWithoutBaseURL(t *testing.T) {
+	cfg := &config.Config{Title: "Example", Image: "images/cover.png"}
+
+	post := newTestPage("blog/post.md", "/blog/post.html", &frontmatter.FrontMatter{Title: "Post"})
+	about := newTestPage("about.md", "/about.html", &frontmatter.FrontMatter{
+		Title:  "About",
+		Params: map[string]any{"image": "https://cdn.example.com/me.png"},
+	})
+
+	if _, err := New(cfg, []*Page{post, about}, nil, nil); err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	tests := []struct {
+		name      string
+		page      *Page
+		wantImage string
+		wantCard  string
+	}{
+		{"shouldLeaveOutRelativeURLs", post, "", TWITTER_CARD_SUMMARY},
+		{
+			"shouldKeepAbsoluteImage", about, "https://cdn.example.com/me.png",
+			TWITTER_CARD_SUMMARY_LARGE_IMAGE,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			metadata := tt.page.Metadata
+			if metadata.URL != "" || metadata.Image != tt.wantImage ||
+				metadata.TwitterCard != tt.wantCard || metadata.StructuredData["url"] != nil {
+				t.Errorf(
+					"Metadata = %+v, want no URL, image %q and card %q",
+					metadata, tt.wantImage, tt.wantCard,
+				)
+			}
+		})
+	}
+}
+
+func TestNew_MetadataWithPathInBaseURL(t *testing.T) {
+	cfg := &config.Config{BaseURL: "https://example.com/sub", Image: "/images/cover.png"}
+
+	post := newTestPage("blog/post.md", "/blog/post.html", &frontmatter.FrontMatter{
+		Title:  "Post",
+		Params: map[string]any{"image": "/blog/bundle/map.png"},
+	})
+	about := newTestPage("about.md", "/about.html", &frontmatter.FrontMatter{Title: "About"})
+
+	if _, err := New(cfg, []*Page{post, about}, nil, nil); err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	tests := []struct {
+		name      string
+		page      *Page
+		wantImage string
+	}{
+		{
+			"shouldKeepPathOfBaseURLForImageFrontMatter", post,
+			"https://example.com/sub/blog/bundle/map.png",
+		},
+		{
+			"shouldKeepPathOfBaseURLForImageOfConfig", about,
+			"https://example.com/sub/images/cover.png",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.page.Metadata.Image != tt.wantImage {
+				t.Errorf("Metadata.Image = %q, want %q", tt.page.Metadata.Image, tt.wantImage)
+			}
+		})
+	}
+}
@@ -0,0 +1,62 @@
+package site
+
+// Breadcrumb is a link to an ancestor of a page, e.g. to a section the page is in.
+type Breadcrumb struct {
+	Title string
+	URL   string
+}
+
+// addNavigation sets breadcrumbs of all pages and list pages, and links pages of every section to
+// their neighbours. Sections' pages must already be sorted and list pages of taxonomies and terms
+// must already be generated.
+func (site *Site) addNavigation() {
+	for _, page := range site.Pages {
+		switch {
+		case page.IsHome():
+			page.Breadcrumbs = []*Breadcrumb{}
+		default:
+			page.Breadcrumbs = sectionBreadcrumbs(page.CurrentSection)
+		}
+	}
+
+	for _, listPage := range site.ListPages {
+		switch {
+		case listPage.Kind == PAGE_KIND_TAXONOMY:
+			listPage.Breadcrumbs = sectionBreadcrumbs(site.Home)
+		case listPage.Kind == PAGE_KIND_TERM:
+			taxonomy := listPage.Term.Taxonomy
+			listPage.Breadcrumbs = append(
+				sectionBreadcrumbs(site.Home), &Breadcrumb{taxonomy.Title, taxonomy.URL},
+			)
+		default:
+			listPage.Breadcrumbs = sectionBreadcrumbs(listPage.CurrentSection.Parent)
+		}
+	}
+
+	for _, section := range site.sections {
+		for i, page := range section.Pages {
+			if i > 0 {
+				page.PrevInSection = section.Pages[i-1]
+			}
+
+			if i < len(section.Pages)-1 {
+				page.NextInSection = section.Pages[i+1]
+			}
+		}
+	}
+}
+
+// sectionBreadcrumbs returns breadcrumbs of section and its ancestors, starting from the home
+// section, or none if section is nil.
+func sectionBreadcrumbs(section *Section) []*Breadcrumb {
+	if section == nil {
+		return []*Breadcrumb{}
+	}
+
+	url := section.URL
+	if section.Page != nil {
+		url = section.Page.URL
+	}
+
+	return append(sectionBreadcrumbs(section.Parent), &Breadcrumb{section.Title, url})
+}
@@ -0,0 +1,92 @@
+package site
+
+import (
+	"testing"
+	"time"
+
+	"static-site-generator/pkg/config"
+	"static-site-generator/pkg/frontmatter"
+)
+
+func TestNew_Navigation(t *testing.T) {
+	day := time.Date(2024, 10, 1, 0, 0, 0, 0, time.UTC)
+
+	home := newTestPage("index.md", "/", &frontmatter.FrontMatter{Title: "Home"})
+	docsIndex := newTestPage("docs/_index.md", "/docs/", &frontmatter.FrontMatter{Title: "Docs"})
+	docsIndex.Kind = PAGE_KIND_SECTION
+	install := newTestPage(
+		"docs/guide/install.md", "/docs/guide/install.html",
+		&frontmatter.FrontMatter{Title: "Install", Weight: 1},
+	)
+	usage := newTestPage(
+		"docs/guide/usage.md", "/docs/guide/usage.html",
+		&frontmatter.FrontMatter{Title: "Usage", Weight: 2},
+	)
+	faq := newTestPage(
+		"docs/guide/faq.md", "/docs/guide/faq.html", &frontmatter.FrontMatter{Title: "FAQ", Date: day},
+	)
+
+	site, err := New(
+		&config.Config{Title: "My Site", ContentDir: "content", DestinationDir: "public"},
+		[]*Page{home, docsIndex, install, usage, faq}, nil, nil,
+	)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	breadcrumbTests := []struct {
+		name string
+		page *Page
+		want []Breadcrumb
+	}{
+		{"homePage", home, []Breadcrumb{}},
+		{"sectionPage", docsIndex, []Breadcrumb{{"My Site", "/"}}},
+		{
+			"subsectionPage", site.GetSection("docs/guide").Page,
+			[]Breadcrumb{{"My Site", "/"}, {"Docs", "/docs/"}},
+		},
+		{
+			"nestedPage", install,
+			[]Breadcrumb{{"My Site", "/"}, {"Docs", "/docs/"}, {"Guide", "/docs/guide/"}},
+		},
+	}
+
+	for _, tt := range breadcrumbTests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := []Breadcrumb{}
+			for _, breadcrumb := range tt.page.Breadcrumbs {
+				got = append(got, *breadcrumb)
+			}
+
+			if len(got) != len(tt.want) {
+				t.Fatalf("Breadcrumbs = %v, want %v", got, tt.want)
+			}
+
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("Breadcrumbs = %v, want %v", got, tt.want)
+				}
+			}
+		})
+	}
+
+	neighbourTests := []struct {
+		page     *Page
+		wantPrev *Page
+		wantNext *Page
+	}{
+		{install, nil, usage},
+		{usage, install, faq},
+		{faq, usage, nil},
+		{home, nil, nil},
+	}
+
+	for _, tt := range neighbourTests {
+		if tt.page.PrevInSection != tt.wantPrev || tt.page.NextInSection != tt.wantNext {
+			t.Errorf(
+				"%s: PrevInSection = %v, NextInSection = %v, want %v and %v",
+				tt.page.Title, tt.page.PrevInSection, tt.page.NextInSection, tt.wantPrev, tt.wantNext,
+			)
+		}
+	}
+}
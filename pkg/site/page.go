@@ -0,0 +1,243 @@
+package site
+
+import (
+	"errors"
+	"fmt"
+	"html/template"
+	"os"
+	"path"
+	"strings"
+
+	"static-site-generator/pkg/adapters"
+	"static-site-generator/pkg/config"
+	"static-site-generator/pkg/frontmatter"
+	hn "static-site-generator/pkg/htmlnodes"
+	"static-site-generator/pkg/manifest"
+	"static-site-generator/pkg/markdown"
+)
+
+type Page struct {
+	*frontmatter.FrontMatter
+
+	// Kind is PAGE_KIND_SECTION, PAGE_KIND_TAXONOMY or PAGE_KIND_TERM for list pages of
+	// sections, taxonomies and terms, and PAGE_KIND_PAGE for other pages.
+	Kind string
+	// Title is front matter's title, or the first heading of the page if front matter has none.
+	// List pages without either are titled after their section.
+	Title   string
+	Content template.HTML
+	// Summary is the content before the <!--more--> divider, or else the first paragraph of the
+	// page cut after summaryLength words of the config.
+	Summary template.HTML
+	// PlainSummary is Summary without HTML tags, e.g. for meta descriptions.
+	PlainSummary string
+	// Truncated reports whether Summary is only a part of Content, e.g. for "Read more" links.
+	Truncated bool
+	// Tree is the HTML node tree Content is generated from, nil if the page has no content.
+	Tree *hn.ParentNode
+	URL  string
+	// Section is the top level directory of content directory the page is in, it is used to look
+	// up the page's layout.
+	Section string
+	// CurrentSection is the section that lists the page (or the section itself for list pages),
+	// it is set by New.
+	CurrentSection  *Section
+	SourcePath      string
+	DestinationPath string
+	SourceHash      string
+
+	// Pages are pages listed by a list page of a section or a term, on the current page of its
+	// Paginator.
+	Pages []*Page
+	// Paginator is set for list pages of sections and terms.
+	Paginator *Paginator
+	// Taxonomy is set for list pages of taxonomies.
+	Taxonomy *Taxonomy
+	// Term is set for list pages of terms.
+	Term *Term
+	// Feed is set for list pages of sections and terms and for the home page, see Site.Feeds.
+	Feed *Feed
+	// Resources are files other than Markdown files in the page's directory and its
+	// subdirectories without pages, sorted by name. Only pages of directories (index.md and list
+	// pages of sections) have resources.
+	Resources []*Resource
+
+	// Breadcrumbs are links to ancestors of the page, starting from the home section: sections
+	// of directories the page is in and, for term pages, the taxonomy. They don't include the
+	// page itself and are empty for the home page.
+	Breadcrumbs []*Breadcrumb
+	// PrevInSection and NextInSection are the neighbours of the page among pages of its section,
+	// in the order of Section.Pages, nil for the first and the last page and for list pages.
+	PrevInSection *Page
+	NextInSection *Page
+
+	// Language is the language of the page, it is set by New.
+	Language *Language
+	// Translations are translations of the page into other languages, in the order of
+	// Site.Languages, they are set by LinkTranslations. Pages are translations of each other if
+	// they have the same path relative to content directory (ignoring language suffixes and
+	// content directories of languages), or the same translationKey front matter.
+	Translations []*Page
+	// Metadata describes the page for link previews and search engines, it is set by New.
+	Metadata *Metadata
+
+	// terms are terms the page uses, keyed by taxonomy names.
+	terms map[string][]*Term
+	// relativePath is the source path relative to content directory (or to the content directory
+	// of the page's language), slash separated and without language suffix.
+	relativePath string
+	// summaryBlocks is the number of top level blocks of Tree before the <!--more--> divider.
+	summaryBlocks int
+	// hasSummaryDivider reports whether the page has the <!--more--> divider, so that a divider
+	// before the first block gives an empty summary instead of an automatic one.
+	hasSummaryDivider bool
+	// summaryLength is the number of words automatic summaries are cut after, see Config.
+	summaryLength int
+	// dir is the directory of the source file relative to content directory, "" for the root.
+	dir string
+}
+
+// LoadPage reads source file, parses its front matter and Markdown and extracts page's title and
+// summary. _index.md files are loaded as list pages of their directories' sections. cfg is the
+// config of the page's language, see LanguageOf and config.ForLanguage. It is safe to call
+// concurrently for different pages.
+func LoadPage(sourcePath string, cfg *config.Config) (*Page, error) {
+	sourceBytes, err := os.ReadFile(sourcePath)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"loading page failed, couldn't read source file (%s): %v", sourcePath, err,
+		)
+	}
+
+	frontMatter, sourceMarkdown, err := frontmatter.Parse(string(sourceBytes))
+	if err != nil {
+		return nil, fmt.Errorf(
+			"loading page failed, couldn't parse front matter of source file (%s): %v",
+			sourcePath, err,
+		)
+	}
+
+	kind := PAGE_KIND_PAGE
+	if SourceFileName(cfg, sourcePath) == SECTION_INDEX_FILE {
+		kind = PAGE_KIND_SECTION
+	}
+
+	title := frontMatter.Title
+	if title == "" {
+		title, err = markdown.ExtractMarkdownTitle(sourceMarkdown)
+		if errors.Is(err, markdown.ErrMissingMarkdownTitle) && kind == PAGE_KIND_SECTION {
+			title, err = "", nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf(
+				"loading page (%s) failed, couldn't extract title from markdown: %v",
+				sourcePath, err,
+			)
+		}
+	}
+
+	var tree *hn.ParentNode
+	content, summaryBlocks, hasSummaryDivider := "", 0, false
+
+	if strings.TrimSpace(sourceMarkdown) != "" {
+		tree, err = adapters.MarkdownToHTMLNode(separateSummaryDivider(sourceMarkdown))
+		if err != nil {
+			return nil, fmt.Errorf(
+				"loading page (%s) failed, couldn't transform markdown to HTML node: %v",
+				sourcePath, err,
+			)
+		}
+
+		summaryBlocks, hasSummaryDivider = removeSummaryDivider(tree)
+		if len(tree.Children) == 0 {
+			tree = nil
+		}
+	}
+
+	if tree != nil {
+		content, err = tree.ToHTML()
+		if err != nil {
+			return nil, fmt.Errorf(
+				"loading page (%s) failed, couldn't transform HTML Node to HTML: %v",
+				sourcePath, err,
+			)
+		}
+	}
+
+	relativeSourcePath, err := contentRelativePath(cfg, sourcePath)
+	if err != nil {
+		return nil, fmt.Errorf("loading page failed, %v", err)
+	}
+
+	relativeSourcePath = path.Join(dirOf(relativeSourcePath), SourceFileName(cfg, sourcePath))
+
+	url, err := pageURL(cfg, relativeSourcePath, frontMatter, title)
+	if err != nil {
+		return nil, fmt.Errorf("loading page (%s) failed: %v", sourcePath, err)
+	}
+
+	url = cfg.LanguagePrefix + url
+
+	page := &Page{
+		FrontMatter:       frontMatter,
+		Kind:              kind,
+		Title:             title,
+		Content:           template.HTML(content),
+		Tree:              tree,
+		URL:               url,
+		Section:           sectionOf(relativeSourcePath),
+		SourcePath:        sourcePath,
+		DestinationPath:   urlToDestinationPath(cfg, url),
+		SourceHash:        manifest.HashBytes(sourceBytes),
+		summaryBlocks:     summaryBlocks,
+		hasSummaryDivider: hasSummaryDivider,
+		summaryLength:     cfg.SummaryLength,
+		relativePath:      relativeSourcePath,
+		dir:               dirOf(relativeSourcePath),
+	}
+
+	if err := page.summarize(); err != nil {
+		return nil, fmt.Errorf("loading page (%s) failed, couldn't summarize: %v", sourcePath, err)
+	}
+
+	return page, nil
+}
+
+// IsHome reports whether the page is the index page of content directory, or the list page of the
+// home section.
+func (page *Page) IsHome() bool {
+	return page.dir == "" &&
+		(page.Kind == PAGE_KIND_SECTION || path.Base(page.relativePath) == INDEX_FILE)
+}
+
+// IsSection reports whether the page is a list page of a section.
+func (page *Page) IsSection() bool {
+	return page.Kind == PAGE_KIND_SECTION
+}
+
+// IsList reports whether the page is a list page of a section, a taxonomy or a term.
+func (page *Page) IsList() bool {
+	return page.Kind != PAGE_KIND_PAGE
+}
+
+// sectionOf returns the top level directory of source file's slash separated path relative to
+// content directory, or an empty string for files placed directly in content directory.
+func sectionOf(relativeSourcePath string) string {
+	section, _, found := strings.Cut(relativeSourcePath, "/")
+	if !found {
+		return ""
+	}
+
+	return section
+}
+
+// dirOf returns the directory of source file's slash separated path relative to content
+// directory, "" for files placed directly in content directory.
+func dirOf(relativeSourcePath string) string {
+	dir := path.Dir(relativeSourcePath)
+	if dir == "." {
+		return ""
+	}
+
+	return dir
+}
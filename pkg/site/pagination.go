@@ -0,0 +1,91 @@
+package site
+
+import (
+	"fmt"
+
+	"static-site-generator/pkg/config"
+)
+
+// Paginator is one page of pages listed by a list page.
+type Paginator struct {
+	// PageNumber is the number of the current page, starting from 1.
+	PageNumber int
+	TotalPages int
+	// Pages are pages listed on the current page.
+	Pages    []*Page
+	FirstURL string
+	LastURL  string
+	// PrevURL is empty on the first page.
+	PrevURL string
+	// NextURL is empty on the last page.
+	NextURL string
+}
+
+func (paginator *Paginator) HasPrev() bool {
+	return paginator.PrevURL != ""
+}
+
+func (paginator *Paginator) HasNext() bool {
+	return paginator.NextURL != ""
+}
+
+// paginate splits pages listed by listPage into pages of pageSize pages each, or keeps all of
+// them on one page if pageSize is zero. listPage becomes the first page, the other pages are
+// copies of it at URLs like /blog/page/2/, which are returned.
+func paginate(cfg *config.Config, listPage *Page, pageSize int) []*Page {
+	pages := listPage.Pages
+
+	totalPages := 1
+	if pageSize > 0 && len(pages) > pageSize {
+		totalPages = (len(pages) + pageSize - 1) / pageSize
+	}
+
+	pageURL := func(pageNumber int) string {
+		if pageNumber == 1 {
+			return listPage.URL
+		}
+
+		return fmt.Sprintf("%spage/%d/", listPage.URL, pageNumber)
+	}
+
+	otherPages := []*Page{}
+
+	for pageNumber := 1; pageNumber <= totalPages; pageNumber++ {
+		paginator := &Paginator{
+			PageNumber: pageNumber,
+			TotalPages: totalPages,
+			Pages:      pages,
+			FirstURL:   pageURL(1),
+			LastURL:    pageURL(totalPages),
+		}
+
+		if totalPages > 1 {
+			start := (pageNumber - 1) * pageSize
+			paginator.Pages = pages[start:min(start+pageSize, len(pages))]
+		}
+
+		if pageNumber > 1 {
+			paginator.PrevURL = pageURL(pageNumber - 1)
+		}
+
+		if pageNumber < totalPages {
+			paginator.NextURL = pageURL(pageNumber + 1)
+		}
+
+		page := listPage
+		if pageNumber > 1 {
+			pageCopy := *listPage
+			page = &pageCopy
+
+			page.URL = pageURL(pageNumber)
+			page.DestinationPath = urlToDestinationPath(cfg, page.URL)
+
+			otherPages = append(otherPages, page)
+		}
+
+		page.Pages = paginator.Pages
+		page.Paginator = paginator
+	}
+
+	return otherPages
+}
@@ -0,0 +1,95 @@
+package site
+
+import (
+	"reflect"
+	"testing"
+
+	"static-site-generator/pkg/config"
+)
+
+func TestPaginate(t *testing.T) {
+	cfg := &config.Config{DestinationDir: "public"}
+
+	listedPages := []*Page{}
+	for _, title := range []string{"1", "2", "3", "4", "5"} {
+		listedPages = append(listedPages, &Page{Title: title})
+	}
+
+	listPage := &Page{Kind: PAGE_KIND_SECTION, URL: "/blog/", Pages: listedPages}
+
+	otherPages := paginate(cfg, listPage, 2)
+
+	if len(otherPages) != 2 {
+		t.Fatalf("paginate() returned %d page(s), want 2", len(otherPages))
+	}
+
+	tests := []struct {
+		page            *Page
+		wantURL         string
+		wantDestination string
+		wantPaginator   Paginator
+		wantTitles      []string
+	}{
+		{
+			page:          listPage,
+			wantURL:       "/blog/",
+			wantPaginator: Paginator{1, 3, nil, "/blog/", "/blog/page/3/", "", "/blog/page/2/"},
+			wantTitles:    []string{"1", "2"},
+		},
+		{
+			page:            otherPages[0],
+			wantURL:         "/blog/page/2/",
+			wantDestination: "public/blog/page/2/index.html",
+			wantPaginator: Paginator{
+				2, 3, nil, "/blog/", "/blog/page/3/", "/blog/", "/blog/page/3/",
+			},
+			wantTitles: []string{"3", "4"},
+		},
+		{
+			page:            otherPages[1],
+			wantURL:         "/blog/page/3/",
+			wantDestination: "public/blog/page/3/index.html",
+			wantPaginator: Paginator{
+				3, 3, nil, "/blog/", "/blog/page/3/", "/blog/page/2/", "",
+			},
+			wantTitles: []string{"5"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.wantURL, func(t *testing.T) {
+			if tt.page.URL != tt.wantURL || tt.page.DestinationPath != tt.wantDestination {
+				t.Errorf(
+					"page at (%s, %s), want (%s, %s)",
+					tt.page.URL, tt.page.DestinationPath, tt.wantURL, tt.wantDestination,
+				)
+			}
+
+			paginator := *tt.page.Paginator
+			paginator.Pages = nil
+
+			if !reflect.DeepEqual(paginator, tt.wantPaginator) {
+				t.Errorf("Paginator = %+v, want %+v", paginator, tt.wantPaginator)
+			}
+
+			assertPageTitles(t, "Paginator.Pages", tt.page.Paginator.Pages, tt.wantTitles...)
+			assertPageTitles(t, "Pages", tt.page.Pages, tt.wantTitles...)
+		})
+	}
+}
+
+func TestPaginate_Disabled(t *testing.T) {
+	listPage := &Page{URL: "/tags/go/", Pages: []*Page{{Title: "1"}, {Title: "2"}, {Title: "3"}}}
+
+	if otherPages := paginate(&config.Config{}, listPage, 0); len(otherPages) != 0 {
+		t.Errorf("paginate() returned %d page(s), want none", len(otherPages))
+	}
+
+	paginator := listPage.Paginator
+	if paginator.TotalPages != 1 || paginator.HasPrev() || paginator.HasNext() ||
+		paginator.FirstURL != "/tags/go/" || paginator.LastURL != "/tags/go/" {
+		t.Errorf("Paginator = %+v, want a single page", paginator)
+	}
+
+	assertPageTitles(t, "Paginator.Pages", paginator.Pages, "1", "2", "3")
+}
@@ -0,0 +1,132 @@
+package site
+
+import (
+	"fmt"
+	"mime"
+	"path"
+	"slices"
+	"strings"
+
+	"static-site-generator/pkg/config"
+)
+
+// Resource is a file of content directory other than a Markdown file, e.g. an image placed next
+// to the page that shows it. It is copied next to the page it belongs to.
+type Resource struct {
+	// Name is the path of the resource relative to its page's directory, e.g. "images/cover.png".
+	Name string
+	// MediaType is guessed from the file extension, e.g. "image/png", empty if unknown.
+	MediaType       string
+	URL             string
+	SourcePath      string
+	DestinationPath string
+	// Page is the page the resource belongs to, nil if no page does.
+	Page *Page
+}
+
+// Resource returns the page's resource with name, or nil if the page has no such resource.
+func (page *Page) Resource(name string) *Resource {
+	index := slices.IndexFunc(page.Resources, func(resource *Resource) bool {
+		return resource.Name == name
+	})
+	if index == -1 {
+		return nil
+	}
+
+	return page.Resources[index]
+}
+
+// ResourcesByType returns the page's resources whose media type starts with mediaType, e.g.
+// "image" for all images.
+func (page *Page) ResourcesByType(mediaType string) []*Resource {
+	resources := []*Resource{}
+
+	for _, resource := range page.Resources {
+		if strings.HasPrefix(resource.MediaType, mediaType) {
+			resources = append(resources, resource)
+		}
+	}
+
+	return resources
+}
+
+// addResources turns files at resourcePaths (inside content directory) into resources of the
+// pages they belong to: the page of the nearest directory (the file's own or an ancestor) with
+// index.md, _index.md or a generated list page. Resources keep their path relative to that
+// directory, but are placed relative to the page's URL, so that content/majesty/cover.png of
+// content/majesty/index.md with slug lotr gets URL /lotr/cover.png. It returns resources keyed
+// by their slash separated paths relative to content directory.
+func (site *Site) addResources(
+	cfg *config.Config, resourcePaths []string, pagesByPath map[string]*Page,
+) (map[string]*Resource, error) {
+	resourcesByPath := map[string]*Resource{}
+
+	for _, resourcePath := range resourcePaths {
+		relativePath, err := contentRelativePath(cfg, resourcePath)
+		if err != nil {
+			return nil, fmt.Errorf("resource %v", err)
+		}
+
+		resource := &Resource{
+			Name:       relativePath,
+			MediaType:  mime.TypeByExtension(path.Ext(relativePath)),
+			URL:        cfg.LanguagePrefix + "/" + relativePath,
+			SourcePath: resourcePath,
+		}
+
+		for dir := dirOf(relativePath); ; dir = parentPath(dir) {
+			resource.Page = site.directoryPage(dir, pagesByPath)
+
+			if resource.Page != nil {
+				resource.Name = strings.TrimPrefix(relativePath, dir+"/")
+				resource.URL = pageDirURL(resource.Page) + resource.Name
+				resource.Page.Resources = append(resource.Page.Resources, resource)
+				break
+			}
+
+			if dir == "" {
+				break
+			}
+		}
+
+		resource.DestinationPath = urlToDestinationPath(cfg, resource.URL)
+		resourcesByPath[relativePath] = resource
+		site.Resources = append(site.Resources, resource)
+	}
+
+	slices.SortFunc(site.Resources, func(a, b *Resource) int {
+		return strings.Compare(a.URL, b.URL)
+	})
+
+	for _, page := range slices.Concat(site.Pages, site.ListPages) {
+		slices.SortFunc(page.Resources, func(a, b *Resource) int {
+			return strings.Compare(a.Name, b.Name)
+		})
+	}
+
+	return resourcesByPath, nil
+}
+
+// directoryPage returns the page of directory dir (relative to content directory): its index.md,
+// or else the list page of its section, or nil if it has neither.
+func (site *Site) directoryPage(dir string, pagesByPath map[string]*Page) *Page {
+	if page, found := pagesByPath[path.Join(dir, INDEX_FILE)]; found {
+		return page
+	}
+
+	if section, found := site.sections[dir]; found && section.Page != nil {
+		return section.Page
+	}
+
+	return nil
+}
+
+// pageDirURL returns URL of the directory page's output is in, e.g. /blog/ for both /blog/ and
+// /blog/post.html.
+func pageDirURL(page *Page) string {
+	if strings.HasSuffix(page.URL, "/") {
+		return page.URL
+	}
+
+	return dirURL(strings.TrimPrefix(path.Dir(page.URL), "/"))
+}
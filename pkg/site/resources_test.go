@@ -0,0 +1,79 @@
+package site
+
+import (
+	"testing"
+
+	"static-site-generator/pkg/config"
+	"static-site-generator/pkg/frontmatter"
+)
+
+func TestNew_Resources(t *testing.T) {
+	cfg := &config.Config{ContentDir: "content", DestinationDir: "public"}
+
+	home := newTestPage("index.md", "/", &frontmatter.FrontMatter{Title: "Home"})
+	bundle := newTestPage("majesty/index.md", "/lotr/", &frontmatter.FrontMatter{Title: "Majesty"})
+	post := newTestPage("blog/post.md", "/blog/post.html", &frontmatter.FrontMatter{Title: "Post"})
+
+	site, err := New(cfg, []*Page{home, bundle, post}, nil, []string{
+		"content/favicon.ico",
+		"content/majesty/cover.png",
+		"content/majesty/images/map.jpg",
+		"content/blog/diagram.svg",
+	})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	blog := site.GetSection("blog").Page
+
+	tests := []struct {
+		sourcePath      string
+		name            string
+		url             string
+		destinationPath string
+		page            *Page
+	}{
+		{
+			"content/blog/diagram.svg", "diagram.svg", "/blog/diagram.svg",
+			"public/blog/diagram.svg", blog,
+		},
+		{"content/favicon.ico", "favicon.ico", "/favicon.ico", "public/favicon.ico", home},
+		{
+			"content/majesty/cover.png", "cover.png", "/lotr/cover.png",
+			"public/lotr/cover.png", bundle,
+		},
+		{
+			"content/majesty/images/map.jpg", "images/map.jpg", "/lotr/images/map.jpg",
+			"public/lotr/images/map.jpg", bundle,
+		},
+	}
+
+	if len(site.Resources) != len(tests) {
+		t.Fatalf("len(Resources) = %d, want %d", len(site.Resources), len(tests))
+	}
+
+	for i, tt := range tests {
+		t.Run(tt.sourcePath, func(t *testing.T) {
+			resource := site.Resources[i]
+
+			if resource.SourcePath != tt.sourcePath || resource.Name != tt.name ||
+				resource.URL != tt.url || resource.DestinationPath != tt.destinationPath ||
+				resource.Page != tt.page {
+				t.Errorf("Resources[%d] = %+v, want %+v", i, resource, tt)
+			}
+		})
+	}
+
+	if bundle.Resource("images/map.jpg") != site.Resources[3] ||
+		bundle.Resource("map.jpg") != nil {
+		t.Errorf("Resource() doesn't find resources by their names")
+	}
+
+	if images := bundle.ResourcesByType("image"); len(images) != 2 || images[0].Name != "cover.png" {
+		t.Errorf("ResourcesByType(\"image\") = %v, want cover.png and images/map.jpg", images)
+	}
+
+	if post.Resources != nil {
+		t.Errorf("post.Resources = %v, want nil for pages of files", post.Resources)
+	}
+}
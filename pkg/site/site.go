@@ -0,0 +1,438 @@
+package site
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"static-site-generator/pkg/config"
+	"static-site-generator/pkg/frontmatter"
+	"static-site-generator/pkg/manifest"
+)
+
+// Site holds every published page of the site, so that templates can list and link other pages.
+// It embeds site config, so that {{ .Site.Title }} and other config values keep working.
+type Site struct {
+	*config.Config
+
+	// Pages are all published pages except for list pages, sorted by weight, date (newest first)
+	// and title.
+	Pages []*Page
+	// ListPages are list pages of all sections, taxonomies and terms, including all pages of
+	// paginated ones, sorted by URL.
+	ListPages []*Page
+	// Home is the section of content directory itself.
+	Home *Section
+	// Taxonomies are keyed by their names, e.g. "tags".
+	Taxonomies map[string]*Taxonomy
+	// Data holds files of data directory, see data.Load. It is set by the generator.
+	Data map[string]any
+	// Menus are keyed by their names, e.g. "main".
+	Menus map[string]Menu
+	// Resources are files of content directory other than Markdown files, sorted by URL.
+	Resources []*Resource
+	// Aliases are old URLs of pages listed by their aliases front matter, sorted by URL.
+	Aliases []*Alias
+	// Language is the language of the site's pages. Sites of multilingual configs have pages of
+	// one language each.
+	Language *Language
+	// Feed is the feed of the whole site, nil if the site has no home page.
+	Feed *Feed
+	// Feeds are feeds of the site, of sections and of terms, sorted by URL.
+	Feeds []*Feed
+	// Languages are all languages of the site, sorted by weight (languages without weight last),
+	// the default language first.
+	Languages []*Language
+
+	// sections are keyed by their path.
+	sections map[string]*Section
+}
+
+// Section is a directory of content directory with pages in it.
+type Section struct {
+	// Path is the section's directory relative to content directory, "" for the home section.
+	Path string
+	// Title is the title of section's _index.md, its directory name or, for the home section,
+	// the site title.
+	Title  string
+	URL    string
+	Parent *Section
+	// Page is the list page of the section, loaded from its _index.md or generated if it has
+	// none. It is nil if the section's directory has an index.md, which replaces the list page.
+	Page *Page
+	// Sections are direct subsections of the section, sorted by title.
+	Sections []*Section
+	// Pages are pages placed directly in the section's directory, sorted according to sortBy
+	// front matter of section's _index.md (SORT_BY_WEIGHT by default). Page of a directory's
+	// index.md is listed by the parent section of that directory, so that e.g. majesty/index.md
+	// is listed by the home section. The home page isn't listed.
+	Pages []*Page
+}
+
+// New builds the site of one language from its published pages and content files next to them,
+// cfg is the config of that language (see config.ForLanguage). Pages link to unpublishedPages
+// without failing. It fails if pages, their links, front matter or menus are invalid, or if URLs
+// of pages or aliases collide.
+func New(
+	cfg *config.Config, pages, unpublishedPages []*Page, resourcePaths []string,
+) (*Site, error) {
+	site := &Site{
+		Config:     cfg,
+		Pages:      []*Page{},
+		ListPages:  []*Page{},
+		Taxonomies: map[string]*Taxonomy{},
+		Data:       map[string]any{},
+		Menus:      map[string]Menu{},
+		Resources:  []*Resource{},
+		Aliases:    []*Alias{},
+		Feeds:      []*Feed{},
+		Languages:  newLanguages(cfg),
+		sections:   map[string]*Section{},
+	}
+
+	for _, language := range site.Languages {
+		if language.Code == cfg.LanguageCode {
+			site.Language = language
+		}
+	}
+
+	site.Home = site.ensureSection("")
+	site.Home.Title = cfg.Title
+
+	for _, page := range pages {
+		switch {
+		case page.IsSection():
+			page.CurrentSection = site.ensureSection(page.dir)
+			page.CurrentSection.Page = page
+		case page.IsHome():
+			page.CurrentSection = site.Home
+			site.Pages = append(site.Pages, page)
+		default:
+			sectionPath := page.dir
+			if path.Base(page.relativePath) == INDEX_FILE {
+				sectionPath = parentPath(page.dir)
+			}
+
+			page.CurrentSection = site.ensureSection(sectionPath)
+			page.CurrentSection.Pages = append(page.CurrentSection.Pages, page)
+			site.Pages = append(site.Pages, page)
+		}
+	}
+
+	isURLTaken := map[string]bool{}
+	for _, page := range site.Pages {
+		isURLTaken[page.URL] = true
+	}
+
+	for _, sectionPath := range slices.Sorted(maps.Keys(site.sections)) {
+		section := site.sections[sectionPath]
+
+		if section.Page == nil && !isURLTaken[section.URL] {
+			section.Page = newGeneratedPage(cfg, PAGE_KIND_SECTION, section.Path, section.Title)
+			section.Page.CurrentSection = section
+		}
+
+		if section.Page == nil {
+			continue
+		}
+
+		if section.Page.Title == "" {
+			section.Page.Title = section.Title
+		} else {
+			section.Title = section.Page.Title
+		}
+
+		site.ListPages = append(site.ListPages, section.Page)
+	}
+
+	errs := []error{}
+
+	pagesByPath := map[string]*Page{}
+	for _, page := range pages {
+		pagesByPath[page.relativePath] = page
+	}
+
+	resourcesByPath, err := site.addResources(cfg, resourcePaths, pagesByPath)
+	if err != nil {
+		return nil, err
+	}
+
+	unpublishedByPath := map[string]*Page{}
+	for _, page := range unpublishedPages {
+		unpublishedByPath[page.relativePath] = page
+	}
+
+	for _, page := range pages {
+		if err := resolveLinks(page, pagesByPath, unpublishedByPath, resourcesByPath); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	sortPages(site.Pages, pageComparisons[SORT_BY_WEIGHT])
+
+	for _, section := range site.sections {
+		sortBy := SORT_BY_WEIGHT
+		if section.Page != nil {
+			if value, ok := section.Page.Params["sortBy"].(string); ok {
+				sortBy = value
+			}
+		}
+
+		comparisons, found := pageComparisons[sortBy]
+		if !found {
+			errs = append(errs, fmt.Errorf(
+				"%w (%s) of section (%s), expected %s, %s or %s", ErrInvalidSortBy, sortBy,
+				section.Page.SourcePath, SORT_BY_WEIGHT, SORT_BY_DATE, SORT_BY_TITLE,
+			))
+			continue
+		}
+
+		sortPages(section.Pages, comparisons)
+
+		if section.Page != nil {
+			section.Page.Pages = section.Pages
+		}
+
+		slices.SortFunc(section.Sections, func(a, b *Section) int {
+			return strings.Compare(a.Title, b.Title)
+		})
+	}
+
+	if err := site.addTaxonomies(cfg); err != nil {
+		errs = append(errs, err)
+	}
+
+	for _, name := range cfg.Taxonomies {
+		taxonomy := site.Taxonomies[name]
+		if taxonomy.Page == nil {
+			continue
+		}
+
+		site.ListPages = append(site.ListPages, taxonomy.Page)
+		for _, term := range taxonomy.Terms {
+			site.ListPages = append(site.ListPages, term.Page)
+		}
+	}
+
+	site.addNavigation()
+	site.addFeeds(cfg)
+
+	for _, page := range slices.Concat(site.Pages, site.ListPages) {
+		page.Language = site.Language
+	}
+
+	if err := site.addMenus(cfg, pages); err != nil {
+		errs = append(errs, err)
+	}
+
+	for _, listPage := range slices.Clone(site.ListPages) {
+		if listPage.Kind == PAGE_KIND_TAXONOMY {
+			continue
+		}
+
+		pageSize := cfg.Paginate
+		if _, found := listPage.Params["paginate"]; found {
+			value, err := listPage.Int("paginate")
+			if err == nil && value < 0 {
+				err = fmt.Errorf("paginate (%d) must not be negative", value)
+			}
+			if err != nil {
+				errs = append(errs, fmt.Errorf("page (%s): %w", listPage.SourcePath, err))
+				continue
+			}
+
+			pageSize = value
+		}
+
+		site.ListPages = append(site.ListPages, paginate(cfg, listPage, pageSize)...)
+	}
+
+	slices.SortFunc(site.ListPages, func(a, b *Page) int { return strings.Compare(a.URL, b.URL) })
+
+	if err := site.addMetadata(cfg); err != nil {
+		errs = append(errs, err)
+	}
+
+	pagesByURL := map[string]*Page{}
+
+	for _, page := range slices.Concat(site.Pages, site.ListPages) {
+		if other, found := pagesByURL[page.URL]; found {
+			errs = append(errs, duplicateURLError(other, page))
+		}
+
+		pagesByURL[page.URL] = page
+	}
+
+	pagesByDestinationPath := map[string]*Page{}
+	for _, page := range pagesByURL {
+		pagesByDestinationPath[page.DestinationPath] = page
+	}
+
+	for _, resource := range site.Resources {
+		if page, found := pagesByDestinationPath[resource.DestinationPath]; found {
+			errs = append(errs, fmt.Errorf(
+				"%w: page (%s) and resource (%s) are generated into (%s)",
+				ErrDuplicateURL, page.SourcePath, resource.SourcePath, resource.DestinationPath,
+			))
+		}
+	}
+
+	if err := site.addAliases(cfg, pages, pagesByURL); err != nil {
+		errs = append(errs, err)
+	}
+
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+
+	return site, nil
+}
+
+// newGeneratedPage returns a list page without source, e.g. list page of a section without
+// _index.md, at URL of directory dir (relative to destination directory, after the language
+// prefix). Its source path is dir inside content directory.
+func newGeneratedPage(cfg *config.Config, kind, dir, title string) *Page {
+	url := cfg.LanguagePrefix + dirURL(dir)
+
+	return &Page{
+		FrontMatter:     &frontmatter.FrontMatter{Tags: []string{}, Params: map[string]any{}},
+		Kind:            kind,
+		Title:           title,
+		URL:             url,
+		Section:         sectionOf(path.Join(dir, SECTION_INDEX_FILE)),
+		SourcePath:      filepath.Join(cfg.ContentDir, filepath.FromSlash(dir)),
+		DestinationPath: urlToDestinationPath(cfg, url),
+		// Generated page has no source, but its hash must differ from the empty hash that marks
+		// failed pages in the manifest.
+		SourceHash: manifest.HashBytes(nil),
+		dir:        dir,
+	}
+}
+
+func duplicateURLError(page, other *Page) error {
+	return fmt.Errorf(
+		"%w: pages (%s) and (%s) have URL (%s)",
+		ErrDuplicateURL, page.SourcePath, other.SourcePath, page.URL,
+	)
+}
+
+// GetSection returns section with given path relative to content directory, e.g. "blog", or nil
+// if there is no such section.
+func (site *Site) GetSection(sectionPath string) *Section {
+	return site.sections[strings.Trim(sectionPath, "/")]
+}
+
+func (site *Site) ensureSection(sectionPath string) *Section {
+	if section, found := site.sections[sectionPath]; found {
+		return section
+	}
+
+	section := &Section{
+		Path:     sectionPath,
+		Title:    Titleize(path.Base(sectionPath)),
+		URL:      site.LanguagePrefix + dirURL(sectionPath),
+		Sections: []*Section{},
+		Pages:    []*Page{},
+	}
+
+	if sectionPath != "" {
+		section.Parent = site.ensureSection(parentPath(sectionPath))
+		section.Parent.Sections = append(section.Parent.Sections, section)
+	}
+
+	site.sections[sectionPath] = section
+
+	return section
+}
+
+// IsHome reports whether the section is the home section.
+func (section *Section) IsHome() bool {
+	return section.Parent == nil
+}
+
+// pageComparisons are keyed by values of sortBy front matter of sections' _index.md:
+//   - SORT_BY_WEIGHT sorts by weight (pages without weight last), date (newest first) and title;
+//   - SORT_BY_DATE sorts by date (newest first) and title;
+//   - SORT_BY_TITLE sorts by title.
+var pageComparisons = map[string][]func(a, b *Page) int{
+	SORT_BY_WEIGHT: {compareWeights, compareDates, compareTitles},
+	SORT_BY_DATE:   {compareDates, compareTitles},
+	SORT_BY_TITLE:  {compareTitles},
+}
+
+// sortPages sorts pages by the first of comparisons that tells them apart, pages that are equal
+// by all of them are sorted by source path.
+func sortPages(pages []*Page, comparisons []func(a, b *Page) int) {
+	slices.SortFunc(pages, func(a, b *Page) int {
+		for _, compare := range comparisons {
+			if result := compare(a, b); result != 0 {
+				return result
+			}
+		}
+
+		return strings.Compare(a.SourcePath, b.SourcePath)
+	})
+}
+
+func compareWeights(a, b *Page) int {
+	switch {
+	case a.Weight == b.Weight:
+		return 0
+	case a.Weight == 0:
+		return 1
+	case b.Weight == 0:
+		return -1
+	default:
+		return a.Weight - b.Weight
+	}
+}
+
+func compareDates(a, b *Page) int {
+	return b.Date.Compare(a.Date)
+}
+
+func compareTitles(a, b *Page) int {
+	return strings.Compare(a.Title, b.Title)
+}
+
+func parentPath(sectionPath string) string {
+	parent := path.Dir(sectionPath)
+	if parent == "." {
+		return ""
+	}
+
+	return parent
+}
+
+// Slugify turns text into a lowercase URL path segment, e.g. "Hello, World!" into "hello-world".
+func Slugify(text string) string {
+	var sb strings.Builder
+
+	for _, r := range strings.ToLower(text) {
+		switch {
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			sb.WriteRune(r)
+		case sb.Len() != 0 && !strings.HasSuffix(sb.String(), "-"):
+			sb.WriteByte('-')
+		}
+	}
+
+	return strings.TrimSuffix(sb.String(), "-")
+}
+
+// Titleize turns a file or directory name into a title, e.g. "hello-world" into "Hello World".
+func Titleize(name string) string {
+	words := strings.FieldsFunc(name, func(r rune) bool { return r == '-' || r == '_' || r == ' ' })
+	for i, word := range words {
+		firstRune, size := utf8.DecodeRuneInString(word)
+		words[i] = string(unicode.ToUpper(firstRune)) + word[size:]
+	}
+
+	return strings.Join(words, " ")
+}
@@ -0,0 +1,230 @@
+package site
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"static-site-generator/pkg/config"
+	"static-site-generator/pkg/frontmatter"
+)
+
+func newTestPage(relativeSourcePath, url string, frontMatter *frontmatter.FrontMatter) *Page {
+	return &Page{
+		FrontMatter:  frontMatter,
+		Title:        frontMatter.Title,
+		URL:          url,
+		Section:      sectionOf(relativeSourcePath),
+		SourcePath:   "content/" + relativeSourcePath,
+		relativePath: relativeSourcePath,
+		dir:          dirOf(relativeSourcePath),
+	}
+}
+
+func assertPageTitles(t *testing.T, name string, pages []*Page, want ...string) {
+	t.Helper()
+
+	got := []string{}
+	for _, page := range pages {
+		got = append(got, page.Title)
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("%s = %v, want %v", name, got, want)
+	}
+}
+
+func TestNew(t *testing.T) {
+	day := time.Date(2024, 10, 1, 0, 0, 0, 0, time.UTC)
+
+	home := newTestPage("index.md", "/", &frontmatter.FrontMatter{Title: "Home"})
+	majesty := newTestPage(
+		"majesty/index.md", "/majesty/", &frontmatter.FrontMatter{Title: "Majesty"},
+	)
+	first := newTestPage(
+		"blog/first.md", "/blog/first.html", &frontmatter.FrontMatter{Title: "First", Date: day},
+	)
+	second := newTestPage(
+		"blog/second.md", "/blog/second.html",
+		&frontmatter.FrontMatter{Title: "Second", Date: day.AddDate(0, 0, 1)},
+	)
+	pinned := newTestPage(
+		"blog/pinned.md", "/blog/pinned.html", &frontmatter.FrontMatter{Title: "Pinned", Weight: 1},
+	)
+	nested := newTestPage(
+		"blog/2024/recap/index.md", "/blog/2024/recap/", &frontmatter.FrontMatter{Title: "Recap"},
+	)
+
+	site, err := New(
+		&config.Config{Title: "My Site", ContentDir: "content", DestinationDir: "public"},
+		[]*Page{home, majesty, first, second, pinned, nested}, nil, nil,
+	)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	if site.Home.Title != "My Site" || site.Home.URL != "/" || !site.Home.IsHome() {
+		t.Errorf("Home = %+v, want home section titled after the site", site.Home)
+	}
+
+	assertPageTitles(t, "Pages", site.Pages, "Pinned", "Second", "First", "Home", "Majesty", "Recap")
+
+	assertPageTitles(t, "Home.Pages", site.Home.Pages, "Majesty")
+
+	blog := site.GetSection("blog")
+	if blog == nil {
+		t.Fatalf("GetSection(\"blog\") = nil")
+	}
+
+	if blog.Title != "Blog" || blog.URL != "/blog/" || blog.Parent != site.Home {
+		t.Errorf("blog section = %+v", blog)
+	}
+
+	assertPageTitles(t, "blog.Pages", blog.Pages, "Pinned", "Second", "First")
+
+	year := site.GetSection("/blog/2024/")
+	if year == nil || year.Parent != blog || !reflect.DeepEqual(blog.Sections, []*Section{year}) {
+		t.Fatalf("GetSection(\"/blog/2024/\") = %+v, want subsection of blog", year)
+	}
+
+	assertPageTitles(t, "year.Pages", year.Pages, "Recap")
+
+	if site.GetSection("majesty") != nil {
+		t.Errorf("GetSection(\"majesty\") != nil, directory with only index.md shouldn't be a section")
+	}
+
+	if site.Home.Page != nil {
+		t.Errorf("Home.Page = %+v, want nil, index.md replaces list page", site.Home.Page)
+	}
+
+	if blog.Page == nil || blog.Page.Title != "Blog" || !blog.Page.IsSection() ||
+		blog.Page.DestinationPath != "public/blog/index.html" || blog.Page.CurrentSection != blog {
+		t.Errorf("blog.Page = %+v, want generated list page", blog.Page)
+	}
+
+	assertPageTitles(t, "ListPages", site.ListPages, "Blog", "2024")
+
+	if home.CurrentSection != site.Home ||
+		majesty.CurrentSection != site.Home ||
+		first.CurrentSection != blog {
+		t.Errorf("pages aren't linked to the sections that list them")
+	}
+}
+
+func TestNew_SectionIndex(t *testing.T) {
+	day := time.Date(2024, 10, 1, 0, 0, 0, 0, time.UTC)
+
+	sectionIndex := newTestPage("blog/_index.md", "/blog/", &frontmatter.FrontMatter{
+		Title:  "Posts",
+		Params: map[string]any{"sortBy": "title"},
+	})
+	sectionIndex.Kind = PAGE_KIND_SECTION
+
+	pages := []*Page{
+		sectionIndex,
+		newTestPage("blog/b.md", "/blog/b.html", &frontmatter.FrontMatter{Title: "B", Date: day}),
+		newTestPage("blog/a.md", "/blog/a.html", &frontmatter.FrontMatter{Title: "A", Weight: 2}),
+	}
+
+	site, err := New(&config.Config{}, pages, nil, nil)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	blog := site.GetSection("blog")
+	if blog.Page != sectionIndex || blog.Title != "Posts" || sectionIndex.CurrentSection != blog {
+		t.Errorf("blog section = %+v, want section with list page of _index.md", blog)
+	}
+
+	assertPageTitles(t, "blog.Pages", blog.Pages, "A", "B")
+	assertPageTitles(t, "Pages", site.Pages, "A", "B")
+}
+
+func TestNew_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		pages   func() []*Page
+		wantErr error
+	}{
+		{
+			name: "shouldFailForPagesWithSameURL",
+			pages: func() []*Page {
+				sectionIndex := newTestPage("blog/_index.md", "/blog/", &frontmatter.FrontMatter{})
+				sectionIndex.Kind = PAGE_KIND_SECTION
+
+				return []*Page{
+					sectionIndex,
+					newTestPage("blog/index.md", "/blog/", &frontmatter.FrontMatter{}),
+					newTestPage("blog/post.md", "/blog/post.html", &frontmatter.FrontMatter{}),
+				}
+			},
+			wantErr: ErrDuplicateURL,
+		},
+		{
+			name: "shouldFailForInvalidSortBy",
+			pages: func() []*Page {
+				sectionIndex := newTestPage("blog/_index.md", "/blog/", &frontmatter.FrontMatter{
+					Params: map[string]any{"sortBy": "author"},
+				})
+				sectionIndex.Kind = PAGE_KIND_SECTION
+
+				return []*Page{sectionIndex}
+			},
+			wantErr: ErrInvalidSortBy,
+		},
+		{
+			name: "shouldFailForAliasOfAnotherPage",
+			pages: func() []*Page {
+				return []*Page{
+					newTestPage("blog/old.md", "/blog/old.html", &frontmatter.FrontMatter{}),
+					newTestPage("blog/new.md", "/blog/new.html", &frontmatter.FrontMatter{
+						Params: map[string]any{"aliases": "/blog/old.html"},
+					}),
+				}
+			},
+			wantErr: ErrAliasCollision,
+		},
+		{
+			name: "shouldFailForAliasOfTwoPages",
+			pages: func() []*Page {
+				return []*Page{
+					newTestPage("one.md", "/one.html", &frontmatter.FrontMatter{
+						Params: map[string]any{"aliases": "/old/"},
+					}),
+					newTestPage("two.md", "/two.html", &frontmatter.FrontMatter{
+						Params: map[string]any{"aliases": "/old"},
+					}),
+				}
+			},
+			wantErr: ErrAliasCollision,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := New(&config.Config{}, tt.pages(), nil, nil); !errors.Is(err, tt.wantErr) {
+				t.Errorf("New() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestTitleize(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"blog", "Blog"},
+		{"hello-world_again", "Hello World Again"},
+		{"ágora", "Ágora"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Titleize(tt.name); got != tt.want {
+				t.Errorf("Titleize(%q) = %q, want %q", tt.name, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,209 @@
+package site
+
+import (
+	"html/template"
+	"slices"
+	"strings"
+	"unicode"
+
+	hn "static-site-generator/pkg/htmlnodes"
+	md "static-site-generator/pkg/markdown"
+)
+
+// separateSummaryDivider puts the first SUMMARY_DIVIDER line of markdown into a block of its own,
+// so that it doesn't merge with the paragraph before it. Lines of code blocks aren't dividers.
+func separateSummaryDivider(markdown string) string {
+	blocks := md.MarkdownToBlocks(markdown)
+
+	for i, block := range blocks {
+		if md.MarkdownBlockToBlockType(block) == md.MARKDOWN_BLOCK_TYPE_CODE {
+			continue
+		}
+
+		lines := strings.Split(block, "\n")
+
+		for j, line := range lines {
+			if strings.TrimSpace(line) != SUMMARY_DIVIDER {
+				continue
+			}
+
+			before, after := strings.Join(lines[:j], "\n"), strings.Join(lines[j+1:], "\n")
+			separatedBlocks := slices.Concat(
+				blocks[:i], []string{before, SUMMARY_DIVIDER, after}, blocks[i+1:],
+			)
+
+			return strings.Join(separatedBlocks, "\n\n")
+		}
+	}
+
+	return markdown
+}
+
+// removeSummaryDivider removes the paragraph of SUMMARY_DIVIDER from top level blocks of tree
+// and returns the number of blocks before it and whether tree has a divider.
+func removeSummaryDivider(tree *hn.ParentNode) (int, bool) {
+	for i, child := range tree.Children {
+		paragraph, ok := child.(*hn.ParentNode)
+		if !ok || paragraph.Tag != "p" || len(paragraph.Children) != 1 {
+			continue
+		}
+
+		text, ok := paragraph.Children[0].(*hn.LeafNode)
+		if ok && text.Tag == "" && strings.TrimSpace(text.Value) == SUMMARY_DIVIDER {
+			tree.Children = append(tree.Children[:i], tree.Children[i+1:]...)
+			return i, true
+		}
+	}
+
+	return 0, false
+}
+
+// summarize sets Summary, PlainSummary and Truncated of the page from its Tree: the blocks before
+// the <!--more--> divider (none if it is the first block), or else the first paragraph cut after
+// summaryLength words.
+func (page *Page) summarize() error {
+	page.Summary, page.PlainSummary, page.Truncated = "", "", false
+
+	if page.Tree == nil {
+		return nil
+	}
+
+	blocks := page.Tree.Children[:page.summaryBlocks]
+	isCut := false
+
+	if !page.hasSummaryDivider {
+		for _, child := range page.Tree.Children {
+			if paragraph, ok := child.(*hn.ParentNode); ok && paragraph.Tag == "p" {
+				paragraph, isCut = truncateParagraph(paragraph, page.summaryLength)
+				blocks = []hn.HTMLStringer{paragraph}
+				break
+			}
+		}
+	}
+
+	summary := strings.Builder{}
+	plainSummary := []string{}
+
+	for _, block := range blocks {
+		blockHTML, err := block.ToHTML()
+		if err != nil {
+			return err
+		}
+
+		summary.WriteString(blockHTML)
+		plainSummary = append(plainSummary, plainText(block))
+	}
+
+	page.Summary = template.HTML(summary.String())
+	page.PlainSummary = strings.Join(strings.Fields(strings.Join(plainSummary, " ")), " ")
+	page.Truncated = isCut || len(page.Tree.Children) > len(blocks)
+
+	return nil
+}
+
+// truncateParagraph returns paragraph, or its copy cut after words words and ended with an
+// ellipsis if it has more of them, and whether it was cut. Zero words keeps the whole paragraph.
+func truncateParagraph(paragraph *hn.ParentNode, words int) (*hn.ParentNode, bool) {
+	if words <= 0 {
+		return paragraph, false
+	}
+
+	truncated, _, isCut := truncateNode(paragraph, words)
+	if !isCut {
+		return paragraph, false
+	}
+
+	ellipsis, _ := hn.NewLeafNode("", "…", nil)
+	truncatedParagraph := truncated.(*hn.ParentNode)
+	truncatedParagraph.Children = append(truncatedParagraph.Children, ellipsis)
+
+	return truncatedParagraph, true
+}
+
+// truncateNode returns node, or its copy with at most words words of text if it has more, the
+// number of words the returned node has and whether it was cut. Cut nodes drop children after
+// the one that ran out of words.
+func truncateNode(node hn.HTMLStringer, words int) (hn.HTMLStringer, int, bool) {
+	switch node := node.(type) {
+	case *hn.LeafNode:
+		value, count, isCut := cutWords(node.Value, words)
+		if !isCut {
+			return node, count, false
+		}
+
+		leafCopy := *node
+		leafCopy.Value = value
+
+		return &leafCopy, count, true
+	case *hn.ParentNode:
+		children := []hn.HTMLStringer{}
+		total := 0
+
+		for _, child := range node.Children {
+			if total == words && strings.TrimSpace(plainText(child)) != "" {
+				return parentNodeCopy(node, children), total, true
+			}
+
+			truncatedChild, count, isCut := truncateNode(child, words-total)
+			children = append(children, truncatedChild)
+			total += count
+
+			if isCut {
+				return parentNodeCopy(node, children), total, true
+			}
+		}
+
+		return node, total, false
+	default:
+		return node, 0, false
+	}
+}
+
+func parentNodeCopy(node *hn.ParentNode, children []hn.HTMLStringer) *hn.ParentNode {
+	nodeCopy := *node
+	nodeCopy.Children = children
+
+	return &nodeCopy
+}
+
+// cutWords returns text cut before its word after the first words words (without the spaces
+// before it), the number of words of the returned text and whether it was cut.
+func cutWords(text string, words int) (string, int, bool) {
+	count := 0
+	isInWord := false
+
+	for i, r := range text {
+		if unicode.IsSpace(r) {
+			isInWord = false
+			continue
+		}
+
+		if !isInWord {
+			if count == words {
+				return strings.TrimRightFunc(text[:i], unicode.IsSpace), count, true
+			}
+
+			isInWord = true
+			count++
+		}
+	}
+
+	return text, count, false
+}
+
+// plainText returns text of node and its descendants without HTML tags.
+func plainText(node hn.HTMLStringer) string {
+	switch node := node.(type) {
+	case *hn.LeafNode:
+		return node.Value
+	case *hn.ParentNode:
+		text := strings.Builder{}
+		for _, child := range node.Children {
+			text.WriteString(plainText(child))
+		}
+
+		return text.String()
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,125 @@
+package site
+
+import (
+	"html/template"
+	"testing"
+
+	"static-site-generator/pkg/adapters"
+)
+
+func TestPage_Summarize(t *testing.T) {
+	tests := []struct {
+		name             string
+		markdown         string
+		summaryLength    int
+		wantSummary      template.HTML
+		wantPlainSummary string
+		wantTruncated    bool
+		wantContent      string
+	}{
+		{
+			name:             "shouldUseFirstParagraph",
+			markdown:         "# Title\n\nFirst **bold** paragraph.\n\nSecond paragraph.",
+			summaryLength:    70,
+			wantSummary:      "<p>First <b>bold</b> paragraph.</p>",
+			wantPlainSummary: "First bold paragraph.",
+			wantTruncated:    true,
+			wantContent: "<div><h1>Title</h1><p>First <b>bold</b> paragraph.</p>" +
+				"<p>Second paragraph.</p></div>",
+		},
+		{
+			name:             "shouldCutFirstParagraphAfterSummaryLengthWords",
+			markdown:         "One two *three four* five six.",
+			summaryLength:    3,
+			wantSummary:      "<p>One two <i>three</i>…</p>",
+			wantPlainSummary: "One two three…",
+			wantTruncated:    true,
+			wantContent:      "<div><p>One two <i>three four</i> five six.</p></div>",
+		},
+		{
+			name:             "shouldCutBeforeNodeAfterLastWord",
+			markdown:         "One two *three* four.",
+			summaryLength:    3,
+			wantSummary:      "<p>One two <i>three</i>…</p>",
+			wantPlainSummary: "One two three…",
+			wantTruncated:    true,
+			wantContent:      "<div><p>One two <i>three</i> four.</p></div>",
+		},
+		{
+			name:             "shouldKeepShortParagraph",
+			markdown:         "Just this.",
+			summaryLength:    3,
+			wantSummary:      "<p>Just this.</p>",
+			wantPlainSummary: "Just this.",
+			wantTruncated:    false,
+			wantContent:      "<div><p>Just this.</p></div>",
+		},
+		{
+			name:             "shouldUseContentBeforeDivider",
+			markdown:         "# Title\n\nFirst paragraph.\n<!--more-->\nRest of the page.",
+			summaryLength:    1,
+			wantSummary:      "<h1>Title</h1><p>First paragraph.</p>",
+			wantPlainSummary: "Title First paragraph.",
+			wantTruncated:    true,
+			wantContent:      "<div><h1>Title</h1><p>First paragraph.</p><p>Rest of the page.</p></div>",
+		},
+		{
+			name:             "shouldHaveEmptySummaryForDividerBeforeFirstBlock",
+			markdown:         "<!--more-->\n\nFirst paragraph.",
+			summaryLength:    70,
+			wantSummary:      "",
+			wantPlainSummary: "",
+			wantTruncated:    true,
+			wantContent:      "<div><p>First paragraph.</p></div>",
+		},
+		{
+			name:             "shouldIgnoreDividerInCodeBlock",
+			markdown:         "Intro.\n\n```\nfirst()\n<!--more-->\nsecond()\n```\n\nAfter.",
+			summaryLength:    70,
+			wantSummary:      "<p>Intro.</p>",
+			wantPlainSummary: "Intro.",
+			wantTruncated:    true,
+			wantContent: "<div><p>Intro.</p>" +
+				"<pre><code>first()\n<!--more-->\nsecond()\n</code></pre><p>After.</p></div>",
+		},
+		{
+			name:             "shouldNotTruncateSummaryOfDividerAtTheEnd",
+			markdown:         "First paragraph.\n\n<!--more-->",
+			summaryLength:    70,
+			wantSummary:      "<p>First paragraph.</p>",
+			wantPlainSummary: "First paragraph.",
+			wantTruncated:    false,
+			wantContent:      "<div><p>First paragraph.</p></div>",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tree, err := adapters.MarkdownToHTMLNode(separateSummaryDivider(tt.markdown))
+			if err != nil {
+				t.Fatalf("MarkdownToHTMLNode() error = %v", err)
+			}
+
+			page := &Page{Tree: tree, summaryLength: tt.summaryLength}
+			page.summaryBlocks, page.hasSummaryDivider = removeSummaryDivider(tree)
+
+			if err := page.summarize(); err != nil {
+				t.Fatalf("summarize() error = %v", err)
+			}
+
+			content, err := tree.ToHTML()
+			if err != nil {
+				t.Fatalf("ToHTML() error = %v", err)
+			}
+
+			if page.Summary != tt.wantSummary || page.PlainSummary != tt.wantPlainSummary ||
+				page.Truncated != tt.wantTruncated || content != tt.wantContent {
+				t.Errorf(
+					"summarize() = %q, %q, %t, content %q, want %q, %q, %t, content %q",
+					page.Summary, page.PlainSummary, page.Truncated, content,
+					tt.wantSummary, tt.wantPlainSummary, tt.wantTruncated, tt.wantContent,
+				)
+			}
+		})
+	}
+}
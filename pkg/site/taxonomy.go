@@ -0,0 +1,137 @@
+package site
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"static-site-generator/pkg/config"
+)
+
+// Taxonomy groups pages by values of a front matter key, e.g. tags.
+type Taxonomy struct {
+	// Name is the front matter key, e.g. "tags".
+	Name  string
+	Title string
+	URL   string
+	// Terms are sorted by slug.
+	Terms []*Term
+	// Page is the list page of the taxonomy, nil if no page uses the taxonomy.
+	Page *Page
+}
+
+// Term is a value of a taxonomy, e.g. a tag, with the pages that use it.
+type Term struct {
+	// Name is the term as written in front matter of the first page that uses it. Terms that
+	// differ only in case or punctuation, e.g. "Go" and "go", are the same term.
+	Name     string
+	Slug     string
+	URL      string
+	Taxonomy *Taxonomy
+	// Pages are pages that use the term, sorted by date (newest first) and title.
+	Pages []*Page
+	// Page is the list page of the term.
+	Page *Page
+}
+
+// Count returns the number of pages that use the term.
+func (term *Term) Count() int {
+	return len(term.Pages)
+}
+
+// TermsByCount returns terms of the taxonomy sorted by the number of their pages (the most used
+// first) and name, e.g. for tag clouds.
+func (taxonomy *Taxonomy) TermsByCount() []*Term {
+	terms := slices.Clone(taxonomy.Terms)
+
+	slices.SortStableFunc(terms, func(a, b *Term) int {
+		return b.Count() - a.Count()
+	})
+
+	return terms
+}
+
+// Terms returns terms of the taxonomy the page uses, in the order of its front matter.
+func (page *Page) Terms(taxonomy string) []*Term {
+	return page.terms[taxonomy]
+}
+
+// addTaxonomies groups pages into terms of every taxonomy of cfg and generates list pages of
+// taxonomies and terms that pages use. Pages must already be sorted.
+func (site *Site) addTaxonomies(cfg *config.Config) error {
+	errs := []error{}
+
+	for _, name := range cfg.Taxonomies {
+		taxonomy := &Taxonomy{
+			Name:  name,
+			Title: Titleize(name),
+			URL:   cfg.LanguagePrefix + "/" + name + "/",
+			Terms: []*Term{},
+		}
+		termsBySlug := map[string]*Term{}
+
+		for _, page := range site.Pages {
+			termNames, err := page.Strings(name)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("page (%s): %w", page.SourcePath, err))
+				continue
+			}
+
+			for _, termName := range termNames {
+				slug := Slugify(termName)
+				if slug == "" {
+					errs = append(errs, fmt.Errorf(
+						"page (%s): %s (%s) must contain letters or digits",
+						page.SourcePath, name, termName,
+					))
+					continue
+				}
+
+				term, found := termsBySlug[slug]
+				if !found {
+					term = &Term{
+						Name:     strings.TrimSpace(termName),
+						Slug:     slug,
+						URL:      taxonomy.URL + slug + "/",
+						Taxonomy: taxonomy,
+						Pages:    []*Page{},
+					}
+					termsBySlug[slug] = term
+				}
+
+				if slices.Contains(term.Pages, page) {
+					continue
+				}
+
+				term.Pages = append(term.Pages, page)
+
+				if page.terms == nil {
+					page.terms = map[string][]*Term{}
+				}
+				page.terms[name] = append(page.terms[name], term)
+			}
+		}
+
+		for _, slug := range slices.Sorted(maps.Keys(termsBySlug)) {
+			term := termsBySlug[slug]
+			sortPages(term.Pages, pageComparisons[SORT_BY_DATE])
+
+			term.Page = newGeneratedPage(cfg, PAGE_KIND_TERM, name+"/"+slug, term.Name)
+			term.Page.Term = term
+			term.Page.Pages = term.Pages
+
+			taxonomy.Terms = append(taxonomy.Terms, term)
+		}
+
+		if len(taxonomy.Terms) != 0 {
+			taxonomy.Page = newGeneratedPage(cfg, PAGE_KIND_TAXONOMY, name, taxonomy.Title)
+			taxonomy.Page.Taxonomy = taxonomy
+		}
+
+		site.Taxonomies[name] = taxonomy
+	}
+
+	return errors.Join(errs...)
+}
@@ -0,0 +1,134 @@
+package site
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"static-site-generator/pkg/config"
+	"static-site-generator/pkg/frontmatter"
+)
+
+func newTaxonomyTestPage(name string, date time.Time, params map[string]any) *Page {
+	frontMatter, err := frontmatter.New(params)
+	if err != nil {
+		panic(err)
+	}
+
+	frontMatter.Title, frontMatter.Date = name, date
+
+	return newTestPage("blog/"+name+".md", "/blog/"+name+".html", frontMatter)
+}
+
+func assertTermNames(t *testing.T, name string, terms []*Term, want ...string) {
+	t.Helper()
+
+	got := []string{}
+	for _, term := range terms {
+		got = append(got, term.Name)
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("%s = %v, want %v", name, got, want)
+	}
+}
+
+func TestNew_Taxonomies(t *testing.T) {
+	day := time.Date(2024, 10, 1, 0, 0, 0, 0, time.UTC)
+
+	older := newTaxonomyTestPage("older", day, map[string]any{
+		"tags":       []any{"Go", "Tolkien"},
+		"categories": "Reviews",
+	})
+	newer := newTaxonomyTestPage("newer", day.AddDate(0, 0, 1), map[string]any{
+		"tags": []any{"tolkien", "go!"},
+	})
+	untagged := newTaxonomyTestPage("untagged", day, map[string]any{})
+
+	cfg := &config.Config{
+		ContentDir:     "content",
+		DestinationDir: "public",
+		Taxonomies:     []string{"tags", "categories", "series"},
+	}
+
+	site, err := New(cfg, []*Page{older, newer, untagged}, nil, nil)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	tags := site.Taxonomies["tags"]
+	// Terms are named after the first page in Site.Pages that uses them, the newer one.
+	assertTermNames(t, "tags.Terms", tags.Terms, "go!", "tolkien")
+
+	tolkien := tags.Terms[1]
+	if tolkien.Slug != "tolkien" || tolkien.URL != "/tags/tolkien/" || tolkien.Count() != 2 {
+		t.Errorf("tolkien term = %+v", tolkien)
+	}
+
+	assertPageTitles(t, "tolkien.Pages", tolkien.Pages, "newer", "older")
+	assertPageTitles(t, "tolkien.Page.Pages", tolkien.Page.Pages, "newer", "older")
+
+	if tolkien.Page.Kind != PAGE_KIND_TERM || tolkien.Page.Term != tolkien ||
+		tolkien.Page.DestinationPath != "public/tags/tolkien/index.html" {
+		t.Errorf("tolkien.Page = %+v, want list page of the term", tolkien.Page)
+	}
+
+	if tags.Page == nil || tags.Page.URL != "/tags/" || tags.Page.Taxonomy != tags {
+		t.Errorf("tags.Page = %+v, want list page of the taxonomy", tags.Page)
+	}
+
+	assertTermNames(t, "older.Terms(\"tags\")", older.Terms("tags"), "go!", "tolkien")
+
+	if got := untagged.Terms("tags"); len(got) != 0 {
+		t.Errorf("untagged.Terms(\"tags\") = %v, want none", got)
+	}
+
+	assertTermNames(t, "categories.Terms", site.Taxonomies["categories"].Terms, "Reviews")
+
+	if series := site.Taxonomies["series"]; len(series.Terms) != 0 || series.Page != nil {
+		t.Errorf("series = %+v, want taxonomy without terms and list page", series)
+	}
+}
+
+func TestNew_TaxonomyErrors(t *testing.T) {
+	page := newTaxonomyTestPage("page", time.Time{}, map[string]any{
+		"categories": []any{map[string]any{"name": "reviews"}},
+	})
+
+	_, err := New(&config.Config{Taxonomies: []string{"categories"}}, []*Page{page}, nil, nil)
+	if !errors.Is(err, frontmatter.ErrInvalidFrontMatterField) {
+		t.Errorf("New() error = %v, want %v", err, frontmatter.ErrInvalidFrontMatterField)
+	}
+}
+
+func TestTermsByCount(t *testing.T) {
+	taxonomy := &Taxonomy{Terms: []*Term{
+		{Name: "a", Pages: []*Page{{}}},
+		{Name: "b", Pages: []*Page{{}, {}}},
+		{Name: "c", Pages: []*Page{{}}},
+	}}
+
+	assertTermNames(t, "TermsByCount()", taxonomy.TermsByCount(), "b", "a", "c")
+}
+
+func TestSlugify(t *testing.T) {
+	tests := []struct {
+		text string
+		want string
+	}{
+		{"go", "go"},
+		{"Hello, World!", "hello-world"},
+		{"  Lord of the Rings  ", "lord-of-the-rings"},
+		{"ქართული ენა", "ქართული-ენა"},
+		{"!!!", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			if got := Slugify(tt.text); got != tt.want {
+				t.Errorf("Slugify(%q) = %q, want %q", tt.text, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,150 @@
+package site
+
+import (
+	"fmt"
+	"net/url"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"static-site-generator/pkg/config"
+	"static-site-generator/pkg/frontmatter"
+)
+
+var permalinkTokenRegexp = regexp.MustCompile(`:[a-z]+`)
+
+// pageURL returns URL of the page with source file at relativeSourcePath (slash separated and
+// relative to content directory). By default foo/bar.md gets /foo/bar.html, or /foo/bar/ with
+// pretty URLs, and foo/index.md gets /foo/. Front matter's slug replaces the file name (or the
+// directory name for index.md), it must be a single path segment. Permalink pattern of page's
+// section overrides both.
+func pageURL(
+	cfg *config.Config, relativeSourcePath string, frontMatter *frontmatter.FrontMatter, title string,
+) (string, error) {
+	dir := dirOf(relativeSourcePath)
+	fileName := path.Base(relativeSourcePath)
+
+	if fileName == SECTION_INDEX_FILE || relativeSourcePath == INDEX_FILE {
+		return dirURL(dir), nil
+	}
+
+	sectionPath := dir
+	name := strings.TrimSuffix(fileName, ".md")
+
+	if fileName == INDEX_FILE {
+		sectionPath = parentPath(dir)
+		name = path.Base(dir)
+	}
+
+	slug := strings.Trim(frontMatter.Slug, "/ ")
+	if strings.ContainsAny(slug, "/\\") || slug == "." || slug == ".." {
+		return "", fmt.Errorf("%w (%s), expected a name like my-post", ErrInvalidSlug, frontMatter.Slug)
+	}
+
+	if slug == "" {
+		slug = name
+	}
+
+	pattern, found := cfg.Permalinks[sectionOf(relativeSourcePath)]
+	if !found {
+		switch {
+		case fileName == INDEX_FILE || cfg.PrettyURLs:
+			return dirURL(path.Join(sectionPath, slug)), nil
+		default:
+			return "/" + path.Join(sectionPath, slug) + ".html", nil
+		}
+	}
+
+	var err error
+
+	url := permalinkTokenRegexp.ReplaceAllStringFunc(pattern, func(token string) string {
+		isDateToken := token == ":year" || token == ":month" || token == ":day"
+		if isDateToken && frontMatter.Date.IsZero() && err == nil {
+			err = fmt.Errorf("permalink (%s) uses %s, but page has no date", pattern, token)
+		}
+
+		switch token {
+		case ":year":
+			return frontMatter.Date.Format("2006")
+		case ":month":
+			return frontMatter.Date.Format("01")
+		case ":day":
+			return frontMatter.Date.Format("02")
+		case ":section":
+			return sectionOf(relativeSourcePath)
+		case ":sections":
+			return sectionPath
+		case ":slug":
+			return slug
+		case ":filename":
+			return name
+		case ":title":
+			return Slugify(title)
+		default:
+			if err == nil {
+				err = fmt.Errorf("permalink (%s) has unknown token %s", pattern, token)
+			}
+
+			return token
+		}
+	})
+	if err != nil {
+		return "", err
+	}
+
+	cleanURL := path.Clean("/" + url)
+	if strings.HasSuffix(url, "/") && cleanURL != "/" {
+		cleanURL += "/"
+	}
+
+	return cleanURL, nil
+}
+
+// dirURL returns URL of directory dir (slash separated and relative to destination directory).
+func dirURL(dir string) string {
+	if dir == "" {
+		return "/"
+	}
+
+	return "/" + dir + "/"
+}
+
+// urlToDestinationPath returns path of the file page with url is generated into, e.g.
+// public/foo/index.html for /foo/. url is cleaned from the site root first, so that the path
+// stays inside destination directory even if url has .. segments.
+func urlToDestinationPath(cfg *config.Config, url string) string {
+	relativePath := filepath.FromSlash(strings.TrimPrefix(path.Clean("/"+url), "/"))
+
+	if strings.HasSuffix(url, "/") {
+		return filepath.Join(cfg.DestinationDir, relativePath, "index.html")
+	}
+
+	return filepath.Join(cfg.DestinationDir, relativePath)
+}
+
+// AbsoluteURL returns reference made absolute for the page at pageURL of the site at baseURL, e.g.
+// for links of its content in feeds. Root-relative references are joined onto baseURL, keeping its
+// path (so that /a.png of https://example.com/sub is https://example.com/sub/a.png), other
+// relative references are resolved against the page's URL and absolute ones are kept.
+func AbsoluteURL(baseURL, pageURL, reference string) (string, error) {
+	referenceURL, err := url.Parse(reference)
+	if err != nil {
+		return "", err
+	}
+
+	if referenceURL.IsAbs() || strings.HasPrefix(reference, "//") {
+		return reference, nil
+	}
+
+	if strings.HasPrefix(reference, "/") {
+		return baseURL + reference, nil
+	}
+
+	base, err := url.Parse(baseURL + pageURL)
+	if err != nil {
+		return "", err
+	}
+
+	return base.ResolveReference(referenceURL).String(), nil
+}
@@ -0,0 +1,127 @@
+package site
+
+import (
+	"testing"
+	"time"
+
+	"static-site-generator/pkg/config"
+	"static-site-generator/pkg/frontmatter"
+)
+
+func TestPageURL(t *testing.T) {
+	date := time.Date(2024, 5, 3, 0, 0, 0, 0, time.UTC)
+	permalinks := map[string]string{
+		"blog": "/:section/:year/:month/:slug/",
+		"docs": "/:sections/:title.html",
+	}
+
+	tests := []struct {
+		name               string
+		relativeSourcePath string
+		prettyURLs         bool
+		frontMatter        *frontmatter.FrontMatter
+		want               string
+		wantErr            bool
+	}{
+		{"shouldMapHomePage", "index.md", true, &frontmatter.FrontMatter{}, "/", false},
+		{"shouldMapSectionIndex", "notes/_index.md", false, &frontmatter.FrontMatter{}, "/notes/", false},
+		{"shouldMapPage", "notes/bar.md", false, &frontmatter.FrontMatter{}, "/notes/bar.html", false},
+		{"shouldMapPrettyPage", "notes/bar.md", true, &frontmatter.FrontMatter{}, "/notes/bar/", false},
+		{"shouldMapIndex", "majesty/index.md", false, &frontmatter.FrontMatter{}, "/majesty/", false},
+		{
+			"shouldReplaceFileNameWithSlug", "notes/bar.md", false,
+			&frontmatter.FrontMatter{Slug: "baz"}, "/notes/baz.html", false,
+		},
+		{
+			"shouldReplaceDirectoryNameOfIndexWithSlug", "notes/majesty/index.md", false,
+			&frontmatter.FrontMatter{Slug: "lotr"}, "/notes/lotr/", false,
+		},
+		{
+			"shouldExpandPermalink", "blog/2024/post.md", false,
+			&frontmatter.FrontMatter{Date: date}, "/blog/2024/05/post/", false,
+		},
+		{
+			"shouldUseSlugInPermalink", "blog/post.md", false,
+			&frontmatter.FrontMatter{Date: date, Slug: "hello"}, "/blog/2024/05/hello/", false,
+		},
+		{
+			"shouldExpandPermalinkOfIndex", "blog/trip/index.md", false,
+			&frontmatter.FrontMatter{Date: date}, "/blog/2024/05/trip/", false,
+		},
+		{
+			"shouldExpandFilePermalink", "docs/guides/setup.md", true,
+			&frontmatter.FrontMatter{Title: "Getting Started!"}, "/docs/guides/getting-started.html", false,
+		},
+		{
+			"shouldFailForSlugWithParentDirectory", "notes/bar.md", false,
+			&frontmatter.FrontMatter{Slug: "../../escaped"}, "", true,
+		},
+		{
+			"shouldFailForSlugOfParentDirectory", "notes/majesty/index.md", true,
+			&frontmatter.FrontMatter{Slug: ".."}, "", true,
+		},
+		{
+			"shouldFailForPermalinkWithDateOfUndatedPage", "blog/post.md", false,
+			&frontmatter.FrontMatter{}, "", true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &config.Config{PrettyURLs: tt.prettyURLs, Permalinks: permalinks}
+
+			got, err := pageURL(cfg, tt.relativeSourcePath, tt.frontMatter, tt.frontMatter.Title)
+			if got != tt.want || (err != nil) != tt.wantErr {
+				t.Errorf("pageURL() = (%q, %v), want (%q, error: %v)", got, err, tt.want, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestURLToDestinationPath(t *testing.T) {
+	cfg := &config.Config{DestinationDir: "public"}
+
+	tests := []struct {
+		url  string
+		want string
+	}{
+		{"/", "public/index.html"},
+		{"/majesty/", "public/majesty/index.html"},
+		{"/blog/post.html", "public/blog/post.html"},
+		{"/../../escaped/", "public/escaped/index.html"},
+		{"/blog/../../escaped.html", "public/escaped.html"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.url, func(t *testing.T) {
+			if got := urlToDestinationPath(cfg, tt.url); got != tt.want {
+				t.Errorf("urlToDestinationPath(%q) = %q, want %q", tt.url, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAbsoluteURL(t *testing.T) {
+	tests := []struct {
+		name      string
+		reference string
+		want      string
+	}{
+		{
+			"shouldJoinRootRelativeURLOntoBaseURL", "/images/a.png",
+			"https://example.com/sub/images/a.png",
+		},
+		{"shouldResolveRelativeURLAgainstPage", "a.png", "https://example.com/sub/blog/post/a.png"},
+		{"shouldKeepAbsoluteURL", "https://cdn.example.com/a.png", "https://cdn.example.com/a.png"},
+		{"shouldKeepProtocolRelativeURL", "//cdn.example.com/a.png", "//cdn.example.com/a.png"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := AbsoluteURL("https://example.com/sub", "/blog/post/", tt.reference)
+			if err != nil || got != tt.want {
+				t.Errorf("AbsoluteURL(%q) = (%q, %v), want %q", tt.reference, got, err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,18 @@
+package templates
+
+import "errors"
+
+const (
+	DEFAULT_LAYOUTS_DIR = "_default"
+	PARTIALS_DIR        = "partials"
+
+	BASE_LAYOUT     = "baseof"
+	SINGLE_LAYOUT   = "single"
+	LIST_LAYOUT     = "list"
+	TAXONOMY_LAYOUT = "taxonomy"
+	TERM_LAYOUT     = "term"
+
+	LAYOUT_EXTENSION = ".html"
+)
+
+var ErrLayoutNotFound = errors.New("layout not found")
@@ -0,0 +1,128 @@
+package templates
+
+import (
+	"fmt"
+	"html/template"
+	"reflect"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+// FuncMap returns helper functions available in every template. baseURL is used by absURL.
+func FuncMap(baseURL string) template.FuncMap {
+	return template.FuncMap{
+		"safeHTML":   safeHTML,
+		"safeURL":    safeURL,
+		"upper":      strings.ToUpper,
+		"lower":      strings.ToLower,
+		"title":      title,
+		"trim":       strings.TrimSpace,
+		"join":       join,
+		"toString":   toString,
+		"dateFormat": dateFormat,
+		"now":        time.Now,
+		"default":    defaultValue,
+		"absURL":     func(path string) string { return absURL(baseURL, path) },
+		"relURL":     relURL,
+	}
+}
+
+func safeHTML(text string) template.HTML {
+	return template.HTML(text)
+}
+
+func safeURL(text string) template.URL {
+	return template.URL(text)
+}
+
+func title(text string) string {
+	words := strings.Fields(text)
+
+	for i, word := range words {
+		firstRune, size := utf8.DecodeRuneInString(word)
+		words[i] = string(unicode.ToUpper(firstRune)) + word[size:]
+	}
+
+	return strings.Join(words, " ")
+}
+
+func join(separator string, list any) string {
+	value := reflect.ValueOf(list)
+	if !value.IsValid() {
+		return ""
+	}
+
+	if value.Kind() != reflect.Slice && value.Kind() != reflect.Array {
+		return toString(list)
+	}
+
+	items := make([]string, value.Len())
+	for i := range items {
+		items[i] = toString(value.Index(i).Interface())
+	}
+
+	return strings.Join(items, separator)
+}
+
+func toString(value any) string {
+	switch value := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return value
+	case time.Time:
+		return dateFormat(time.DateOnly, value)
+	case []any, []string:
+		return join(", ", value)
+	default:
+		return fmt.Sprint(value)
+	}
+}
+
+func dateFormat(layout string, date time.Time) string {
+	if date.IsZero() {
+		return ""
+	}
+
+	return date.Format(layout)
+}
+
+// defaultValue returns fallback if value is nil or zero value of its type, so that it can be
+// used as {{ .Page.Description | default "No description" }}.
+func defaultValue(fallback, value any) any {
+	reflectValue := reflect.ValueOf(value)
+	if !reflectValue.IsValid() || reflectValue.IsZero() {
+		return fallback
+	}
+
+	if kind := reflectValue.Kind(); kind == reflect.Slice || kind == reflect.Map {
+		if reflectValue.Len() == 0 {
+			return fallback
+		}
+	}
+
+	return value
+}
+
+func isExternalURL(path string) bool {
+	return strings.Contains(path, "://") || strings.HasPrefix(path, "//") ||
+		strings.HasPrefix(path, "mailto:") || strings.HasPrefix(path, "#")
+}
+
+func absURL(baseURL, path string) string {
+	if isExternalURL(path) {
+		return path
+	}
+
+	return strings.TrimSuffix(baseURL, "/") + relURL(path)
+}
+
+func relURL(path string) string {
+	if isExternalURL(path) || strings.HasPrefix(path, "/") {
+		return path
+	}
+
+	return "/" + path
+}
@@ -0,0 +1,95 @@
+package templates
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestJoin(t *testing.T) {
+	tests := []struct {
+		name string
+		list any
+		want string
+	}{
+		{name: "shouldReturnEmptyStringForNil", list: nil, want: ""},
+		{name: "shouldJoinStrings", list: []string{"go", "ssg"}, want: "go, ssg"},
+		{name: "shouldJoinMixedValues", list: []any{"go", 1, true}, want: "go, 1, true"},
+		{name: "shouldFormatNonListValue", list: 42, want: "42"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := join(", ", tt.list); got != tt.want {
+				t.Errorf("join() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestToString(t *testing.T) {
+	tests := []struct {
+		name  string
+		value any
+		want  string
+	}{
+		{name: "shouldReturnEmptyStringForNil", value: nil, want: ""},
+		{name: "shouldFormatDate", value: time.Date(2024, 10, 1, 12, 0, 0, 0, time.UTC), want: "2024-10-01"},
+		{name: "shouldJoinList", value: []any{"go", "ssg"}, want: "go, ssg"},
+		{name: "shouldFormatNumber", value: 4.5, want: "4.5"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := toString(tt.value); got != tt.want {
+				t.Errorf("toString() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDefaultValue(t *testing.T) {
+	tests := []struct {
+		name     string
+		fallback any
+		value    any
+		want     any
+	}{
+		{name: "shouldReturnFallbackForNil", fallback: "x", value: nil, want: "x"},
+		{name: "shouldReturnFallbackForEmptyString", fallback: "x", value: "", want: "x"},
+		{name: "shouldReturnFallbackForEmptySlice", fallback: "x", value: []string{}, want: "x"},
+		{name: "shouldReturnFallbackForZeroTime", fallback: "x", value: time.Time{}, want: "x"},
+		{name: "shouldReturnValue", fallback: "x", value: "y", want: "y"},
+		{name: "shouldReturnFalseFallback", fallback: true, value: false, want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := defaultValue(tt.fallback, tt.value); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("defaultValue() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAbsURL(t *testing.T) {
+	tests := []struct {
+		name    string
+		baseURL string
+		path    string
+		want    string
+	}{
+		{name: "shouldJoinRootRelativePath", baseURL: "https://example.com", path: "/blog/", want: "https://example.com/blog/"},
+		{name: "shouldJoinRelativePath", baseURL: "https://example.com/", path: "blog/", want: "https://example.com/blog/"},
+		{name: "shouldKeepAbsoluteURL", baseURL: "https://example.com", path: "https://go.dev", want: "https://go.dev"},
+		{name: "shouldKeepFragment", baseURL: "https://example.com", path: "#top", want: "#top"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := absURL(tt.baseURL, tt.path); got != tt.want {
+				t.Errorf("absURL() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
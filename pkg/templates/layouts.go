@@ -0,0 +1,225 @@
+package templates
+
+import (
+	"errors"
+	"fmt"
+	"html/template"
+	"io/fs"
+	"maps"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+	"text/template/parse"
+)
+
+// Renderer holds layouts of a layouts directory, each one already combined with its base layout
+// and all partials. If layouts directory doesn't exist, single fallback template is used for
+// every page instead, loading fails if there is none.
+type Renderer struct {
+	LayoutsDir string
+
+	layouts  map[string]*Template
+	fallback *Template
+}
+
+func LoadRenderer(layoutsDir, fallbackTemplatePath, baseURL string) (*Renderer, error) {
+	_, err := os.Stat(layoutsDir)
+	if errors.Is(err, fs.ErrNotExist) {
+		if fallbackTemplatePath == "" {
+			return nil, fmt.Errorf(
+				"loading layouts failed, layouts directory (%s) doesn't exist and no template is set",
+				layoutsDir,
+			)
+		}
+
+		fallback, err := Load(fallbackTemplatePath, baseURL)
+		if err != nil {
+			return nil, err
+		}
+
+		return &Renderer{LayoutsDir: layoutsDir, fallback: fallback}, nil
+	}
+
+	sources, err := readLayoutSources(layoutsDir)
+	if err != nil {
+		return nil, err
+	}
+
+	renderer := &Renderer{LayoutsDir: layoutsDir, layouts: map[string]*Template{}}
+
+	for _, layoutPath := range slices.Sorted(maps.Keys(sources)) {
+		if isPartial(layoutPath) || isBaseLayout(layoutPath) {
+			continue
+		}
+
+		layout, err := renderer.composeLayout(layoutPath, sources, baseURL)
+		if err != nil {
+			return nil, err
+		}
+
+		renderer.layouts[layoutPath] = layout
+	}
+
+	return renderer, nil
+}
+
+// Lookup returns the first existing layout out of layoutNames, looking in section's directory
+// first and in the _default directory after that.
+func (renderer *Renderer) Lookup(section string, layoutNames ...string) (*Template, error) {
+	if renderer.fallback != nil {
+		return renderer.fallback, nil
+	}
+
+	candidates := []string{}
+
+	for _, layoutName := range layoutNames {
+		if layoutName == "" {
+			continue
+		}
+
+		layoutName = strings.TrimSuffix(layoutName, LAYOUT_EXTENSION) + LAYOUT_EXTENSION
+
+		if section != "" {
+			candidates = append(candidates, path.Join(section, layoutName))
+		}
+
+		candidates = append(candidates, path.Join(DEFAULT_LAYOUTS_DIR, layoutName))
+	}
+
+	for _, candidate := range candidates {
+		if layout, found := renderer.layouts[candidate]; found {
+			return layout, nil
+		}
+	}
+
+	for i, candidate := range candidates {
+		candidates[i] = filepath.Join(renderer.LayoutsDir, candidate)
+	}
+
+	return nil, fmt.Errorf("%w, looked for: %s", ErrLayoutNotFound, strings.Join(candidates, ", "))
+}
+
+func readLayoutSources(layoutsDir string) (map[string]string, error) {
+	sources := map[string]string{}
+
+	err := filepath.WalkDir(layoutsDir, func(filePath string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), LAYOUT_EXTENSION) {
+			return nil
+		}
+
+		relativePath, err := filepath.Rel(layoutsDir, filePath)
+		if err != nil {
+			return err
+		}
+
+		sourceBytes, err := os.ReadFile(filePath)
+		if err != nil {
+			return err
+		}
+
+		sources[filepath.ToSlash(relativePath)] = ConvertLegacyPlaceholders(string(sourceBytes))
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("loading layouts failed, couldn't read layouts directory (%s): %v", layoutsDir, err)
+	}
+
+	return sources, nil
+}
+
+// composeLayout parses layout together with all partials and the base layout of its directory
+// (or of the _default directory). Layouts that only define blocks are executed through their
+// base layout, layouts with content of their own are executed directly.
+func (renderer *Renderer) composeLayout(
+	layoutPath string, sources map[string]string, baseURL string,
+) (*Template, error) {
+	fullPath := filepath.Join(renderer.LayoutsDir, layoutPath)
+
+	errorf := func(err error) error {
+		return fmt.Errorf("loading layouts failed, couldn't parse layout (%s): %v", fullPath, err)
+	}
+
+	root := template.New(layoutPath).Funcs(FuncMap(baseURL))
+	usedSources := []string{}
+
+	for _, sourcePath := range slices.Sorted(maps.Keys(sources)) {
+		if !isPartial(sourcePath) {
+			continue
+		}
+
+		if _, err := root.New(sourcePath).Parse(sources[sourcePath]); err != nil {
+			return nil, errorf(err)
+		}
+
+		usedSources = append(usedSources, sourcePath, sources[sourcePath])
+	}
+
+	basePath := findBaseLayout(layoutPath, sources)
+	if basePath != "" {
+		if _, err := root.New(basePath).Parse(sources[basePath]); err != nil {
+			return nil, errorf(err)
+		}
+
+		usedSources = append(usedSources, basePath, sources[basePath])
+	}
+
+	usedSources = append(usedSources, layoutPath, sources[layoutPath])
+
+	if _, err := root.Parse(sources[layoutPath]); err != nil {
+		return nil, errorf(err)
+	}
+
+	entry := layoutPath
+	if basePath != "" && hasOnlyDefinitions(root.Tree) {
+		entry = basePath
+	}
+
+	return &Template{fullPath, hashSources(usedSources...), root, entry}, nil
+}
+
+func findBaseLayout(layoutPath string, sources map[string]string) string {
+	candidates := []string{
+		path.Join(path.Dir(layoutPath), BASE_LAYOUT+LAYOUT_EXTENSION),
+		path.Join(DEFAULT_LAYOUTS_DIR, BASE_LAYOUT+LAYOUT_EXTENSION),
+	}
+
+	for _, candidate := range candidates {
+		if _, found := sources[candidate]; found {
+			return candidate
+		}
+	}
+
+	return ""
+}
+
+func isPartial(layoutPath string) bool {
+	return strings.HasPrefix(layoutPath, PARTIALS_DIR+"/")
+}
+
+func isBaseLayout(layoutPath string) bool {
+	return path.Base(layoutPath) == BASE_LAYOUT+LAYOUT_EXTENSION
+}
+
+// hasOnlyDefinitions reports whether parsed template has nothing but whitespace outside of its
+// {{ define }} actions.
+func hasOnlyDefinitions(tree *parse.Tree) bool {
+	if tree == nil || tree.Root == nil {
+		return true
+	}
+
+	for _, node := range tree.Root.Nodes {
+		textNode, ok := node.(*parse.TextNode)
+		if !ok || strings.TrimSpace(string(textNode.Text)) != "" {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,194 @@
+package templates
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// writeLayouts writes files keyed by their paths relative to dir into dir.
+func writeLayouts(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+
+	for name, content := range files {
+		filePath := filepath.Join(dir, filepath.FromSlash(name))
+
+		if err := os.MkdirAll(filepath.Dir(filePath), 0o755); err != nil {
+			t.Fatalf("MkdirAll() error = %v", err)
+		}
+
+		if err := os.WriteFile(filePath, []byte(content), 0o644); err != nil {
+			t.Fatalf("WriteFile() error = %v", err)
+		}
+	}
+}
+
+func TestRenderer_Lookup(t *testing.T) {
+	tests := []struct {
+		name        string
+		layouts     map[string]string
+		section     string
+		layoutNames []string
+		want        string
+		wantErr     error
+	}{
+		{
+			name: "shouldRenderBlocksThroughDefaultBaseLayout",
+			layouts: map[string]string{
+				"_default/baseof.html": `<main>{{ block "main" . }}Empty{{ end }}</main>`,
+				"_default/single.html": `{{ define "main" }}{{ .Title }}{{ end }}`,
+			},
+			layoutNames: []string{"single"},
+			want:        "<main>Hi</main>",
+		},
+		{
+			name: "shouldPreferBaseLayoutOfSection",
+			layouts: map[string]string{
+				"_default/baseof.html": `<main>{{ block "main" . }}{{ end }}</main>`,
+				"blog/baseof.html":     `<article>{{ block "main" . }}{{ end }}</article>`,
+				"blog/single.html":     `{{ define "main" }}{{ .Title }}{{ end }}`,
+			},
+			section:     "blog",
+			layoutNames: []string{"single"},
+			want:        "<article>Hi</article>",
+		},
+		{
+			name: "shouldRenderLayoutWithContentOfItsOwnDirectly",
+			layouts: map[string]string{
+				"_default/baseof.html": `<main>{{ block "main" . }}{{ end }}</main>`,
+				"_default/single.html": `<p>{{ template "main" . }}</p>{{ define "main" }}{{ .Title }}{{ end }}`,
+			},
+			layoutNames: []string{"single"},
+			want:        "<p>Hi</p>",
+		},
+		{
+			name: "shouldIncludePartials",
+			layouts: map[string]string{
+				"_default/single.html":   `{{ template "partials/header.html" . }}<p>Body</p>`,
+				"partials/header.html":   `<h1>{{ .Title }}</h1>`,
+				"partials/unused.html":   `<footer></footer>`,
+				"_default/baseof.html":   `{{ block "main" . }}{{ end }}`,
+				"partials/nested/a.html": `<a></a>`,
+			},
+			layoutNames: []string{"single"},
+			want:        "<h1>Hi</h1><p>Body</p>",
+		},
+		{
+			name: "shouldPreferLayoutOfSection",
+			layouts: map[string]string{
+				"_default/single.html": `default`,
+				"blog/single.html":     `blog`,
+			},
+			section:     "blog",
+			layoutNames: []string{"single"},
+			want:        "blog",
+		},
+		{
+			name: "shouldFallBackToDefaultLayout",
+			layouts: map[string]string{
+				"_default/single.html": `default`,
+				"blog/list.html":       `blog`,
+			},
+			section:     "blog",
+			layoutNames: []string{"single"},
+			want:        "default",
+		},
+		{
+			name: "shouldLookUpLayoutNamesInOrder",
+			layouts: map[string]string{
+				"_default/post.html": `default post`,
+				"blog/single.html":   `blog single`,
+			},
+			section:     "blog",
+			layoutNames: []string{"", "post.html", "single"},
+			want:        "default post",
+		},
+		{
+			name: "shouldReturnErrLayoutNotFound",
+			layouts: map[string]string{
+				"_default/list.html": `list`,
+			},
+			section:     "blog",
+			layoutNames: []string{"single"},
+			wantErr:     ErrLayoutNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			layoutsDir := t.TempDir()
+			writeLayouts(t, layoutsDir, tt.layouts)
+
+			renderer, err := LoadRenderer(layoutsDir, "", "")
+			if err != nil {
+				t.Fatalf("LoadRenderer() error = %v", err)
+			}
+
+			layout, err := renderer.Lookup(tt.section, tt.layoutNames...)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Lookup() error = %v, want %v", err, tt.wantErr)
+			}
+
+			if err != nil {
+				return
+			}
+
+			got, err := layout.Render(map[string]any{"Title": "Hi"})
+			if err != nil || string(got) != tt.want {
+				t.Errorf("Render() = (%s, %v), want (%s, nil)", got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadRenderer_Fallback(t *testing.T) {
+	dir := t.TempDir()
+	templatePath := filepath.Join(dir, "template.html")
+	writeLayouts(t, dir, map[string]string{"template.html": "<h1>{{ Title }}</h1>"})
+
+	tests := []struct {
+		name         string
+		templatePath string
+		want         string
+		wantErr      bool
+	}{
+		{
+			name:         "shouldUseTemplateForEveryPageWithoutLayoutsDir",
+			templatePath: templatePath,
+			want:         "<h1>Hi</h1>",
+		},
+		{
+			name:    "shouldFailWithoutLayoutsDirAndTemplate",
+			wantErr: true,
+		},
+		{
+			name:         "shouldFailForMissingTemplate",
+			templatePath: filepath.Join(dir, "missing.html"),
+			wantErr:      true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			renderer, err := LoadRenderer(filepath.Join(dir, "layouts"), tt.templatePath, "")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("LoadRenderer() error = %v, want error %t", err, tt.wantErr)
+			}
+
+			if err != nil {
+				return
+			}
+
+			layout, err := renderer.Lookup("blog", "single")
+			if err != nil {
+				t.Fatalf("Lookup() error = %v", err)
+			}
+
+			got, err := layout.Render(map[string]any{"Title": "Hi"})
+			if err != nil || string(got) != tt.want {
+				t.Errorf("Render() = (%s, %v), want (%s, nil)", got, err, tt.want)
+			}
+		})
+	}
+}
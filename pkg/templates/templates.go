@@ -0,0 +1,84 @@
+package templates
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"html/template"
+	"os"
+	"regexp"
+)
+
+type Template struct {
+	Path string
+	// Hash changes whenever the layout, its base layout or any partial changes.
+	Hash string
+
+	template *template.Template
+	// entry is the name of the template to execute, e.g. base layout the template extends.
+	entry string
+}
+
+func Load(path, baseURL string) (*Template, error) {
+	templateBytes, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("loading template failed, couldn't read template file (%s): %v", path, err)
+	}
+
+	source := ConvertLegacyPlaceholders(string(templateBytes))
+
+	parsedTemplate, err := template.New(path).Funcs(FuncMap(baseURL)).Parse(source)
+	if err != nil {
+		return nil, fmt.Errorf("loading template failed, couldn't parse template file (%s): %v", path, err)
+	}
+
+	return &Template{path, hashSources(source), parsedTemplate, path}, nil
+}
+
+func (t *Template) Render(data any) ([]byte, error) {
+	var buffer bytes.Buffer
+
+	if err := t.template.ExecuteTemplate(&buffer, t.entry, data); err != nil {
+		return nil, fmt.Errorf("rendering template (%s) failed: %v", t.Path, err)
+	}
+
+	return buffer.Bytes(), nil
+}
+
+var legacyPlaceholderRegexp = regexp.MustCompile(
+	`{{\s*(Title|Content|Description|Date|Params\.(\w+))\s*}}`,
+)
+
+// ConvertLegacyPlaceholders rewrites placeholders of templates written before templating engine
+// was introduced ({{ Title }}, {{ Content }}, {{ Description }}, {{ Date }} and
+// {{ Params.key }}) into equivalent template actions, so that such templates keep working.
+func ConvertLegacyPlaceholders(text string) string {
+	return legacyPlaceholderRegexp.ReplaceAllStringFunc(text, func(placeholder string) string {
+		submatches := legacyPlaceholderRegexp.FindStringSubmatch(placeholder)
+
+		switch submatches[1] {
+		case "Title":
+			return "{{ .Title }}"
+		case "Content":
+			return "{{ .Content }}"
+		case "Description":
+			return "{{ .Page.Description }}"
+		case "Date":
+			return `{{ dateFormat "2006-01-02" .Page.Date }}`
+		default:
+			return fmt.Sprintf(`{{ index .Page.Params "%s" | toString }}`, submatches[2])
+		}
+	})
+}
+
+func hashSources(sources ...string) string {
+	hash := sha256.New()
+
+	for _, source := range sources {
+		hash.Write([]byte(source))
+		hash.Write([]byte{0})
+	}
+
+	return hex.EncodeToString(hash.Sum(nil))
+}
@@ -0,0 +1,35 @@
+package templates
+
+import "testing"
+
+func TestConvertLegacyPlaceholders(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want string
+	}{
+		{
+			name: "shouldNotChangeTemplateWithoutLegacyPlaceholders",
+			text: "<title>{{ .Title }}</title>{{ if .Page.Draft }}Draft{{ end }}",
+			want: "<title>{{ .Title }}</title>{{ if .Page.Draft }}Draft{{ end }}",
+		},
+		{
+			name: "shouldConvertTitleAndContent",
+			text: "<title>{{ Title }}</title><h1>{{Title}}</h1><article>{{  Content\t}}</article>",
+			want: "<title>{{ .Title }}</title><h1>{{ .Title }}</h1><article>{{ .Content }}</article>",
+		},
+		{
+			name: "shouldConvertFrontMatterPlaceholders",
+			text: "{{ Description }}|{{ Date }}|{{ Params.author }}",
+			want: `{{ .Page.Description }}|{{ dateFormat "2006-01-02" .Page.Date }}|{{ index .Page.Params "author" | toString }}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ConvertLegacyPlaceholders(tt.text); got != tt.want {
+				t.Errorf("ConvertLegacyPlaceholders() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,152 @@
+package watcher
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"maps"
+	"path/filepath"
+	"slices"
+	"time"
+)
+
+const (
+	DEFAULT_INTERVAL = 300 * time.Millisecond
+	DEFAULT_DEBOUNCE = 200 * time.Millisecond
+)
+
+// Watcher polls files and directories (recursively) for changes. Polling only needs the standard
+// library and works the same way on every platform and file system.
+type Watcher struct {
+	Paths []string
+	// Interval is how often paths are polled.
+	Interval time.Duration
+	// Debounce is how long paths must stay unchanged before changes are reported, so that a
+	// burst of changes (e.g. editor saving several files) is reported once.
+	Debounce time.Duration
+}
+
+type fileState struct {
+	modTime time.Time
+	size    int64
+	isDir   bool
+}
+
+type snapshot map[string]fileState
+
+func New(paths ...string) *Watcher {
+	return &Watcher{Paths: paths, Interval: DEFAULT_INTERVAL, Debounce: DEFAULT_DEBOUNCE}
+}
+
+// Watch polls paths until ctx is done and calls onChange with sorted paths of created, modified
+// and deleted files after every debounced burst of changes. onChange is called synchronously,
+// changes made while it runs are reported by the next call. If onChange changes Paths, they are
+// polled from a fresh snapshot, so that files of added paths aren't reported as created.
+func (watcher *Watcher) Watch(ctx context.Context, onChange func(changedPaths []string)) error {
+	previous, err := watcher.snapshot()
+	if err != nil {
+		return fmt.Errorf("watching failed: %v", err)
+	}
+
+	ticker := time.NewTicker(watcher.Interval)
+	defer ticker.Stop()
+
+	pending := map[string]bool{}
+	var lastChange time.Time
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case now := <-ticker.C:
+			current, err := watcher.snapshot()
+			if err != nil {
+				fmt.Printf("Watching failed, will retry: %v\n", err)
+				continue
+			}
+
+			changedPaths := diff(previous, current)
+			previous = current
+
+			if len(changedPaths) != 0 {
+				for _, changedPath := range changedPaths {
+					pending[changedPath] = true
+				}
+
+				lastChange = now
+			}
+
+			if len(pending) != 0 && now.Sub(lastChange) >= watcher.Debounce {
+				paths := slices.Clone(watcher.Paths)
+
+				onChange(slices.Sorted(maps.Keys(pending)))
+				clear(pending)
+
+				if !slices.Equal(paths, watcher.Paths) {
+					fresh, err := watcher.snapshot()
+					if err != nil {
+						fmt.Printf("Watching failed, will retry: %v\n", err)
+						continue
+					}
+
+					previous = fresh
+				}
+			}
+		}
+	}
+}
+
+func (watcher *Watcher) snapshot() (snapshot, error) {
+	result := snapshot{}
+
+	for _, root := range watcher.Paths {
+		err := filepath.WalkDir(root, func(path string, entry fs.DirEntry, err error) error {
+			if errors.Is(err, fs.ErrNotExist) {
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+
+			info, err := entry.Info()
+			if errors.Is(err, fs.ErrNotExist) {
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+
+			result[path] = fileState{info.ModTime(), info.Size(), info.IsDir()}
+
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return result, nil
+}
+
+// diff returns sorted paths that were created, modified or deleted between two snapshots.
+func diff(previous, current snapshot) []string {
+	changedPaths := []string{}
+
+	for path, state := range current {
+		previousState, found := previous[path]
+		if !found || (!state.isDir && previousState != state) {
+			changedPaths = append(changedPaths, path)
+		}
+	}
+
+	for path := range previous {
+		if _, found := current[path]; !found {
+			changedPaths = append(changedPaths, path)
+		}
+	}
+
+	slices.Sort(changedPaths)
+
+	return changedPaths
+}
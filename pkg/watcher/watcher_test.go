@@ -0,0 +1,107 @@
+package watcher
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestDiff(t *testing.T) {
+	before := time.Date(2024, 10, 1, 12, 0, 0, 0, time.UTC)
+	after := before.Add(time.Second)
+
+	tests := []struct {
+		name     string
+		previous snapshot
+		current  snapshot
+		want     []string
+	}{
+		{
+			name:     "shouldReturnEmptySliceForSameSnapshots",
+			previous: snapshot{"content/index.md": {before, 10, false}},
+			current:  snapshot{"content/index.md": {before, 10, false}},
+			want:     []string{},
+		},
+		{
+			name:     "shouldDetectCreatedModifiedAndDeletedFiles",
+			previous: snapshot{"a.md": {before, 1, false}, "b.md": {before, 1, false}, "c.md": {before, 1, false}},
+			current:  snapshot{"a.md": {after, 1, false}, "b.md": {before, 2, false}, "d.md": {before, 1, false}},
+			want:     []string{"a.md", "b.md", "c.md", "d.md"},
+		},
+		{
+			name:     "shouldIgnoreModificationTimeOfDirectories",
+			previous: snapshot{"content": {before, 0, true}},
+			current:  snapshot{"content": {after, 0, true}},
+			want:     []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := diff(tt.previous, tt.current); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("diff() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWatcher_Watch_ChangedPaths(t *testing.T) {
+	dir := t.TempDir()
+	content, static := filepath.Join(dir, "content"), filepath.Join(dir, "static")
+
+	for _, path := range []string{content, static} {
+		if err := os.MkdirAll(path, 0o755); err != nil {
+			t.Fatalf("MkdirAll() error = %v", err)
+		}
+
+		if err := os.WriteFile(filepath.Join(path, "a.txt"), []byte("a"), 0o644); err != nil {
+			t.Fatalf("WriteFile() error = %v", err)
+		}
+	}
+
+	siteWatcher := New(content)
+	siteWatcher.Interval, siteWatcher.Debounce = 5*time.Millisecond, 5*time.Millisecond
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	calls := make(chan []string, 10)
+	done := make(chan error, 1)
+
+	go func() {
+		done <- siteWatcher.Watch(ctx, func(changedPaths []string) {
+			// Like a reloaded config, the first change adds a path to watch.
+			siteWatcher.Paths = []string{content, static}
+			calls <- changedPaths
+		})
+	}()
+
+	time.Sleep(20 * time.Millisecond)
+
+	if err := os.WriteFile(filepath.Join(content, "a.txt"), []byte("ab"), 0o644); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+
+	select {
+	case got := <-calls:
+		if want := []string{filepath.Join(content, "a.txt")}; !reflect.DeepEqual(got, want) {
+			t.Errorf("changedPaths = %v, want %v", got, want)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("Watch() didn't report the change")
+	}
+
+	time.Sleep(100 * time.Millisecond)
+	cancel()
+
+	if err := <-done; err != nil {
+		t.Errorf("Watch() error = %v", err)
+	}
+
+	if len(calls) != 0 {
+		t.Errorf("Watch() reported files of the added path as changes: %v", <-calls)
+	}
+}